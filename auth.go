@@ -0,0 +1,160 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/countvotes"
+	"github.com/itizir/hrv/i18n"
+	"github.com/itizir/hrv/leaderboard"
+	"github.com/itizir/hrv/store"
+)
+
+const authCollection = "auth"
+
+var (
+	// may use every command in every guild, whatever the configuration
+	superuserID = os.Getenv("LEADERBOARD_ADMIN_ID")
+
+	// what members need when a guild has no rule of its own for a command. zero means anyone may use it.
+	defaultPermissions = map[string]int64{
+		countvotes.ApplicationCommand.Name:       discordgo.PermissionManageEvents,
+		countvotes.ApplicationAdminCommand.Name:  discordgo.PermissionManageEvents,
+		leaderboard.ApplicationAdminCommand.Name: discordgo.PermissionManageThreads,
+		ApplicationAuthCommand.Name:              discordgo.PermissionManageServer,
+	}
+)
+
+// authRule lets members use a command if they have all the permissions, or any of the roles.
+// A rule with neither lets anyone use the command.
+type authRule struct {
+	Permissions int64    `json:"permissions,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+}
+
+func (r authRule) allows(m *discordgo.Member) bool {
+	if r.Permissions == 0 && len(r.Roles) == 0 {
+		return true
+	}
+	if r.Permissions != 0 && m.Permissions&r.Permissions == r.Permissions {
+		return true
+	}
+	for _, role := range m.Roles {
+		if slices.Contains(r.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// authConfig holds the rules of a guild, keyed by command path: either a command name,
+// or a command name and a subcommand name separated by a space. Subcommand rules take precedence.
+type authConfig struct {
+	Rules map[string]authRule `json:"rules"`
+}
+
+func loadAuthConfig(guildID string) (authConfig, error) {
+	cfg := authConfig{Rules: make(map[string]authRule)}
+	_, err := store.Get(authCollection, guildID, &cfg)
+	if cfg.Rules == nil {
+		cfg.Rules = make(map[string]authRule)
+	}
+	return cfg, err
+}
+
+func saveAuthConfig(guildID string, cfg authConfig) error {
+	return store.Put(authCollection, guildID, cfg)
+}
+
+func (cfg authConfig) rule(path string) authRule {
+	if r, ok := cfg.Rules[path]; ok {
+		return r
+	}
+	name, _, isSub := strings.Cut(path, " ")
+	if isSub {
+		if r, ok := cfg.Rules[name]; ok {
+			return r
+		}
+	}
+	return authRule{Permissions: defaultPermissions[name]}
+}
+
+// denyFallback drops the rule of a command left without permissions nor roles once roleID was denied,
+// for the rule of its command or the default permissions to apply instead. A subcommand falling back
+// to the rule of its command keeps a copy of it, without the role, as that was just denied.
+func (cfg authConfig) denyFallback(path, roleID string) {
+	delete(cfg.Rules, path)
+	fallback := cfg.rule(path)
+	if !slices.Contains(fallback.Roles, roleID) {
+		return
+	}
+	fallback.Roles = slices.DeleteFunc(slices.Clone(fallback.Roles), func(r string) bool { return r == roleID })
+	if fallback.Permissions == 0 && len(fallback.Roles) == 0 {
+		name, _, _ := strings.Cut(path, " ")
+		fallback = authRule{Permissions: defaultPermissions[name]}
+	}
+	cfg.Rules[path] = fallback
+}
+
+// commandPath returns the command and, if any, subcommand an interaction is about.
+// Components and modals only know about their command.
+func commandPath(name string, i *discordgo.InteractionCreate) string {
+	if i.Type != discordgo.InteractionApplicationCommand && i.Type != discordgo.InteractionApplicationCommandAutocomplete {
+		return name
+	}
+	opts := i.ApplicationCommandData().Options
+	if len(opts) == 1 && opts[0].Type == discordgo.ApplicationCommandOptionSubCommand {
+		return name + " " + opts[0].Name
+	}
+	return name
+}
+
+func authorized(i *discordgo.InteractionCreate, path string) (bool, error) {
+	if i.Member == nil || i.Member.User == nil {
+		return false, nil // commands are only meant for use within guilds
+	}
+	if superuserID != "" && i.Member.User.ID == superuserID {
+		return true, nil
+	}
+	if i.Member.Permissions&discordgo.PermissionAdministrator != 0 {
+		return true, nil
+	}
+	cfg, err := loadAuthConfig(i.GuildID)
+	if err != nil {
+		return false, err
+	}
+	return cfg.rule(path).allows(i.Member), nil
+}
+
+func respondUnauthorized(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.Printer(i.Interaction)
+	msg := p.Sprintf("Sorry, you are not allowed to do that.")
+	if superuserID != "" {
+		msg += " " + p.Sprintf("Ask %s for help!", userMention(superuserID))
+	}
+	return s.InteractionRespond(i.Interaction,
+		&discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: msg,
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+}
+
+// setDefaultPermissions hides commands from members who lack the default permissions.
+// Guild admins can still grant access to other roles in the server's integration settings,
+// in which case they should also allow them with the permissions command.
+func setDefaultPermissions(cmd *discordgo.ApplicationCommand) {
+	if perm, ok := defaultPermissions[cmd.Name]; ok && perm != 0 {
+		cmd.DefaultMemberPermissions = &perm
+	}
+	noDM := false
+	cmd.DMPermission = &noDM
+}
+
+func userMention(id string) string {
+	return (&discordgo.User{ID: id}).Mention()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/countvotes"
+	"github.com/itizir/hrv/leaderboard"
+)
+
+func TestAuthRuleAllows(t *testing.T) {
+	member := &discordgo.Member{Roles: []string{"r1"}, Permissions: discordgo.PermissionManageEvents}
+	tests := []struct {
+		rule authRule
+		want bool
+	}{
+		{authRule{}, true},
+		{authRule{Roles: []string{"r1"}}, true},
+		{authRule{Roles: []string{"r2"}}, false},
+		{authRule{Permissions: discordgo.PermissionManageEvents}, true},
+		{authRule{Permissions: discordgo.PermissionManageServer}, false},
+		{authRule{Permissions: discordgo.PermissionManageServer, Roles: []string{"r1"}}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.allows(member); got != tt.want {
+			t.Errorf("%+v allows = %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestDenyFallback(t *testing.T) {
+	contest := countvotes.ApplicationCommand.Name
+	rank := leaderboard.ApplicationCommand.Name
+	tests := []struct {
+		name  string
+		rules map[string]authRule
+		path  string
+		want  authRule
+	}{
+		{
+			name:  "default permissions",
+			rules: map[string]authRule{contest: {}},
+			path:  contest,
+			want:  authRule{Permissions: defaultPermissions[contest]},
+		},
+		{
+			name:  "rule of the command",
+			rules: map[string]authRule{contest: {Roles: []string{"r2"}}, contest + " edit": {}},
+			path:  contest + " edit",
+			want:  authRule{Roles: []string{"r2"}},
+		},
+		{
+			name:  "rule of the command allowing the role denied",
+			rules: map[string]authRule{contest: {Roles: []string{"r1", "r2"}}, contest + " edit": {}},
+			path:  contest + " edit",
+			want:  authRule{Roles: []string{"r2"}},
+		},
+		{
+			name:  "rule of the command only allowing the role denied",
+			rules: map[string]authRule{contest: {Roles: []string{"r1"}}, contest + " edit": {}},
+			path:  contest + " edit",
+			want:  authRule{Permissions: defaultPermissions[contest]},
+		},
+		{
+			name:  "anyone by default",
+			rules: map[string]authRule{rank: {}},
+			path:  rank,
+			want:  authRule{},
+		},
+	}
+	for _, tt := range tests {
+		cfg := authConfig{Rules: tt.rules}
+		parent := slices.Clone(cfg.Rules[contest].Roles)
+		cfg.denyFallback(tt.path, "r1")
+		got := cfg.rule(tt.path)
+		if got.Permissions != tt.want.Permissions || !slices.Equal(got.Roles, tt.want.Roles) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+		if !slices.Equal(cfg.Rules[contest].Roles, parent) && tt.path != contest {
+			t.Errorf("%s: rule of the command changed to %+v", tt.name, cfg.Rules[contest])
+		}
+	}
+}
@@ -14,22 +14,46 @@ type Handler func(s *discordgo.Session, i *discordgo.InteractionCreate) error
 var (
 	commands = map[*discordgo.ApplicationCommand]Handler{
 		countvotes.ApplicationCommand:       countvotes.Handle,
+		countvotes.ApplicationAdminCommand:  countvotes.HandleAdmin,
 		leaderboard.ApplicationCommand:      leaderboard.Handle,
 		leaderboard.ApplicationAdminCommand: leaderboard.HandleAdmin,
+		ApplicationAuthCommand:              HandleAuth,
 	}
 
-	commandHandlers map[string]Handler
+	// for options with suggestions
+	autocompletes = map[*discordgo.ApplicationCommand]Handler{
+		countvotes.ApplicationCommand:       countvotes.Autocomplete,
+		leaderboard.ApplicationCommand:      leaderboard.Autocomplete,
+		leaderboard.ApplicationAdminCommand: leaderboard.AdminAutocomplete,
+	}
+
+	commandHandlers      map[string]Handler
+	autocompleteHandlers map[string]Handler
+	knownPaths           []string
 )
 
 func init() {
 	commandHandlers = make(map[string]Handler)
 
+	var cmds []*discordgo.ApplicationCommand
 	for c, h := range commands {
 		commandHandlers[c.Name] = h
+		cmds = append(cmds, c)
+	}
+	knownPaths = commandPaths(cmds)
+
+	autocompleteHandlers = make(map[string]Handler)
+	for c, h := range autocompletes {
+		autocompleteHandlers[c.Name] = h
 	}
 }
 
 func interactionHandle(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		autocompleteHandle(s, i)
+		return
+	}
+
 	var name string
 	switch i.Type {
 	case discordgo.InteractionApplicationCommand:
@@ -42,6 +66,15 @@ func interactionHandle(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	}
 
 	if h, ok := commandHandlers[name]; ok {
+		if allowed, err := authorized(i, commandPath(name, i)); err != nil {
+			log.Println("authorization failed:", err)
+			return
+		} else if !allowed {
+			if err := respondUnauthorized(s, i); err != nil {
+				log.Println("unauthorized reply failed:", err)
+			}
+			return
+		}
 		if err := h(s, i); err != nil {
 			log.Println("handler failed:", err)
 		}
@@ -49,3 +82,32 @@ func interactionHandle(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		log.Println("no handler for", name, i)
 	}
 }
+
+// autocompleteHandle gives no suggestions to members who could not use the command anyway.
+func autocompleteHandle(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	name := i.ApplicationCommandData().Name
+	h, ok := autocompleteHandlers[name]
+	if !ok {
+		log.Println("no autocomplete handler for", name, i)
+		return
+	}
+
+	allowed, err := authorized(i, commandPath(name, i))
+	if err != nil {
+		log.Println("authorization failed:", err)
+		return
+	}
+	if !allowed {
+		h = noSuggestions
+	}
+	if err := h(s, i); err != nil {
+		log.Println("autocomplete handler failed:", err)
+	}
+}
+
+func noSuggestions(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: []*discordgo.ApplicationCommandOptionChoice{}},
+	})
+}
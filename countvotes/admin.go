@@ -0,0 +1,425 @@
+package countvotes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+	"golang.org/x/text/message"
+)
+
+const (
+	adminCommandCreate = "create"
+	adminCommandShow   = "show"
+	adminCommandEdit   = "edit"
+	adminCommandDelete = "delete"
+	adminCommandVerify = "verify"
+
+	optionName              = "name"
+	optionPlayed            = "played"
+	optionMain              = "main"
+	optionSecondary         = "secondary"
+	optionMaxVotes          = "max_per_post"
+	optionDeadline          = "deadline"
+	optionMainPlaces        = "places"
+	optionSecondaryPlaces   = "secondary_places"
+	optionHonorable         = "honorable_mentions"
+	optionMultipleWins      = "multiple_wins"
+	optionMinPlayed         = "min_played"
+	optionVoterRole         = "voter_role"
+	optionCoveragePercent   = "min_coverage_percent"
+	optionCoverageCount     = "min_coverage_count"
+	optionExcludeVoter      = "exclude_voter"
+	optionExcludeContestant = "exclude_contestant"
+	optionInclude           = "include"
+	optionHash              = "hash"
+
+	deadlineNone = "none"
+)
+
+var (
+	zero       = 0.
+	one        = 1.
+	hundred    = 100.
+	maxPlacesF = float64(maxPlaces)
+	hashLength = 2 * sha256.Size
+
+	ApplicationAdminCommand = &discordgo.ApplicationCommand{
+		Type:        discordgo.ChatApplicationCommand,
+		Name:        "contest",
+		Description: "Manage contest configurations",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        adminCommandCreate,
+				Description: "Configure a new contest",
+				Options:     append([]*discordgo.ApplicationCommandOption{channelOption()}, settingsOptions()...),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        adminCommandShow,
+				Description: "Show the configuration of a contest",
+				Options:     []*discordgo.ApplicationCommandOption{channelOption()},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        adminCommandEdit,
+				Description: "Edit the configuration of a contest",
+				Options: append(append([]*discordgo.ApplicationCommandOption{channelOption()}, settingsOptions()...),
+					&discordgo.ApplicationCommandOption{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        optionExcludeVoter,
+						Description: "Always ignore the votes of this member",
+					},
+					&discordgo.ApplicationCommandOption{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        optionExcludeContestant,
+						Description: "Always ignore the submissions of this member",
+					},
+					&discordgo.ApplicationCommandOption{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        optionInclude,
+						Description: "Lift standing exclusions of this member",
+					},
+				),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        adminCommandDelete,
+				Description: "Delete the configuration of a contest",
+				Options:     []*discordgo.ApplicationCommandOption{channelOption()},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        adminCommandVerify,
+				Description: "Check a results snapshot against its published hash, and recount from it",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        optionHash,
+						Description: "Snapshot hash published with the results",
+						Required:    true,
+						MinLength:   &hashLength,
+						MaxLength:   hashLength,
+					},
+				},
+			},
+		},
+	}
+)
+
+func channelOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:         discordgo.ApplicationCommandOptionChannel,
+		Name:         optionChannel,
+		Description:  "Forum or text channel of the contest",
+		Required:     true,
+		ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildForum, discordgo.ChannelTypeGuildText},
+	}
+}
+
+func settingsOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        optionName,
+			Description: "Name of the contest",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        optionPlayed,
+			Description: "Emoji marking a submission as played",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        optionMain,
+			Description: "Emoji of the 'best overall' category",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        optionSecondary,
+			Description: "Emojis of the other categories, comma-separated",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        optionMaxVotes,
+			Description: "Max votes a voter may give a single submission",
+			MinValue:    &one,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        optionMainPlaces,
+			Description: "Places awarded in the 'best overall' category",
+			MinValue:    &one,
+			MaxValue:    maxPlacesF,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        optionSecondaryPlaces,
+			Description: "Places awarded in each of the other categories",
+			MinValue:    &one,
+			MaxValue:    maxPlacesF,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        optionHonorable,
+			Description: "Number of honorable mentions for submissions that just missed",
+			MinValue:    &zero,
+			MaxValue:    maxPlacesF,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        optionMultipleWins,
+			Description: "Let a submission win several categories",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        optionMinPlayed,
+			Description: "Submissions voters must have played to be rewarded",
+			MinValue:    &one,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionRole,
+			Name:        optionVoterRole,
+			Description: "Role granted to voters who took part properly",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        optionCoveragePercent,
+			Description: "Percentage of the submissions voters must have played for their votes to count",
+			MinValue:    &zero,
+			MaxValue:    hundred,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        optionCoverageCount,
+			Description: "Number of submissions voters must have played for their votes to count",
+			MinValue:    &zero,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        optionDeadline,
+			Description: "End of voting (UTC), e.g. 2024-05-31 18:00; 'none' to clear",
+		},
+	}
+}
+
+func HandleAdmin(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.Printer(i.Interaction)
+
+	if l := len(i.ApplicationCommandData().Options); l != 1 {
+		return fmt.Errorf("invalid options length in %s: %d", ApplicationAdminCommand.Name, l)
+	}
+	o := i.ApplicationCommandData().Options[0]
+	vals := optionsToDict(o.Options)
+	channel, _ := vals[optionChannel].(string)
+
+	var msg string
+	switch o.Name {
+	case adminCommandCreate, adminCommandEdit:
+		cfg, exists, err := loadConfig(i.GuildID, channel)
+		if err != nil {
+			return err
+		}
+		if o.Name == adminCommandCreate && exists {
+			msg = p.Sprintf("<#%s> already has a contest configuration: use `/%s %s` instead.", channel, ApplicationAdminCommand.Name, adminCommandEdit)
+			break
+		}
+		if o.Name == adminCommandEdit && !exists {
+			msg = p.Sprintf("<#%s> has no contest configuration: use `/%s %s` first.", channel, ApplicationAdminCommand.Name, adminCommandCreate)
+			break
+		}
+		if err := applySettings(&cfg, vals); err != nil {
+			msg = p.Sprintf("Invalid settings: %v.", err)
+			break
+		}
+		if err := saveConfig(cfg); err != nil {
+			msg = p.Sprintf("Failed to save configuration: %v.", err)
+			break
+		}
+		msg = p.Sprintf("Saved!") + "\n\n" + describeConfig(s, p, cfg)
+	case adminCommandShow:
+		cfg, exists, err := loadConfig(i.GuildID, channel)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			msg = p.Sprintf("<#%s> has no contest configuration, so the defaults apply:", channel) + "\n\n"
+		}
+		msg += describeConfig(s, p, cfg)
+	case adminCommandDelete:
+		if err := deleteConfig(i.GuildID, channel); err != nil {
+			return err
+		}
+		msg = p.Sprintf("OK!")
+	case adminCommandVerify:
+		h, _ := vals[optionHash].(string)
+		h = strings.ToLower(strings.TrimSpace(h))
+		if _, err := hex.DecodeString(h); err != nil || len(h) != hashLength {
+			msg = p.Sprintf("Invalid hash.")
+			break
+		}
+		snap, found, valid, err := loadSnapshot(i.GuildID, h)
+		if err != nil {
+			return err
+		}
+		if !found {
+			msg = p.Sprintf("No snapshot stored with this hash.")
+			break
+		}
+		if !valid {
+			msg = p.Sprintf("⚠️ The stored snapshot does NOT match its hash: it was modified after the results were published!")
+			break
+		}
+		es, err := emojis.resolve(s, i.GuildID)
+		if err != nil {
+			es = emojiSet{} // plain names will do
+		}
+		msg = p.Sprintf("✅ The stored snapshot matches its hash: %d submissions in <#%s>. Recounting from it...", len(snap.Posts), snap.Config.Channel) + "\n\n"
+		msg = snap.contest(es).evaluate(p, msg, snap.excludedVoters(), false)
+	default:
+		return fmt.Errorf("undefined subcommand %q", o.Name)
+	}
+
+	return s.InteractionRespond(i.Interaction,
+		&discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: msg,
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+}
+
+func optionsToDict(opts []*discordgo.ApplicationCommandInteractionDataOption) map[string]any {
+	vals := make(map[string]any)
+	for _, o := range opts {
+		vals[o.Name] = o.Value
+	}
+	return vals
+}
+
+func applySettings(cfg *contestConfig, vals map[string]any) error {
+	if v, ok := vals[optionName].(string); ok {
+		cfg.Name = strings.TrimSpace(v)
+	}
+	if v, ok := vals[optionPlayed].(string); ok {
+		cfg.PlayedEmoji = parseEmojiName(v)
+	}
+	if v, ok := vals[optionMain].(string); ok {
+		cfg.MainEmoji = parseEmojiName(v)
+	}
+	if v, ok := vals[optionSecondary].(string); ok {
+		cfg.SecondaryEmojis = nil
+		for _, e := range strings.Split(v, ",") {
+			cfg.SecondaryEmojis = append(cfg.SecondaryEmojis, parseEmojiName(e))
+		}
+	}
+	if v, ok := vals[optionMaxVotes].(float64); ok {
+		cfg.MaxVotesPerPost = int(v)
+	}
+	if v, ok := vals[optionMainPlaces].(float64); ok {
+		cfg.MainPlaces = int(v)
+	}
+	if v, ok := vals[optionSecondaryPlaces].(float64); ok {
+		cfg.SecondaryPlaces = int(v)
+	}
+	if v, ok := vals[optionHonorable].(float64); ok {
+		cfg.HonorableMentions = int(v)
+	}
+	if v, ok := vals[optionMultipleWins].(bool); ok {
+		cfg.MultipleWins = v
+	}
+	if v, ok := vals[optionMinPlayed].(float64); ok {
+		cfg.MinPlayed = int(v)
+	}
+	if v, ok := vals[optionVoterRole].(string); ok {
+		cfg.VoterRole = v
+	}
+	if v, ok := vals[optionCoveragePercent].(float64); ok {
+		cfg.MinCoveragePercent = int(v)
+	}
+	if v, ok := vals[optionCoverageCount].(float64); ok {
+		cfg.MinCoverageCount = int(v)
+	}
+	if v, ok := vals[optionDeadline].(string); ok {
+		if strings.EqualFold(strings.TrimSpace(v), deadlineNone) {
+			cfg.Deadline = time.Time{}
+		} else {
+			t, err := parseDeadline(v)
+			if err != nil {
+				return err
+			}
+			cfg.Deadline = t
+		}
+	}
+	if v, ok := vals[optionExcludeVoter].(string); ok && !slices.Contains(cfg.ExcludedVoters, v) {
+		if len(cfg.ExcludedVoters) >= maxSelections {
+			return errors.New("too many excluded voters")
+		}
+		cfg.ExcludedVoters = append(cfg.ExcludedVoters, v)
+	}
+	if v, ok := vals[optionExcludeContestant].(string); ok && !slices.Contains(cfg.ExcludedContestants, v) {
+		if len(cfg.ExcludedContestants) >= maxSelections {
+			return errors.New("too many excluded contestants")
+		}
+		cfg.ExcludedContestants = append(cfg.ExcludedContestants, v)
+	}
+	if v, ok := vals[optionInclude].(string); ok {
+		cfg.ExcludedVoters = slices.DeleteFunc(cfg.ExcludedVoters, func(u string) bool { return u == v })
+		cfg.ExcludedContestants = slices.DeleteFunc(cfg.ExcludedContestants, func(u string) bool { return u == v })
+	}
+	return nil
+}
+
+func describeConfig(s *discordgo.Session, p *message.Printer, cfg contestConfig) string {
+	es, err := emojis.resolve(s, cfg.GuildID)
+	if err != nil {
+		es = emojiSet{} // plain names will do
+	}
+
+	var secondary []string
+	for _, e := range cfg.SecondaryEmojis {
+		secondary = append(secondary, es.format(e))
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = p.Sprintf("_unnamed_")
+	}
+	str := p.Sprintf("**%s** in <#%s>", name, cfg.Channel) + "\n"
+	str += "- " + p.Sprintf("Played: %s", es.format(cfg.PlayedEmoji)) + "\n"
+	str += "- " + p.Sprintf("Best overall: %s", es.format(cfg.MainEmoji)) + "\n"
+	str += "- " + p.Sprintf("Other categories: %s", strings.Join(secondary, " ")) + "\n"
+	str += "- " + p.Sprintf("Max votes per submission: %d", cfg.MaxVotesPerPost) + "\n"
+	str += "- " + p.Sprintf("Places: %d overall, %d in each other category", cfg.places(cfg.MainEmoji), max(cfg.SecondaryPlaces, 1)) + "\n"
+	str += "- " + p.Sprintf("Honorable mentions: %d", cfg.HonorableMentions) + "\n"
+	if cfg.MultipleWins {
+		str += "- " + p.Sprintf("A submission may win several categories") + "\n"
+	}
+	if cfg.MinCoveragePercent > 0 || cfg.MinCoverageCount > 0 {
+		str += "- " + p.Sprintf("Votes only count for voters who played at least %d%% or %d of the submissions, whichever is more", cfg.MinCoveragePercent, cfg.MinCoverageCount) + "\n"
+	}
+	if cfg.VoterRole != "" {
+		str += "- " + p.Sprintf("Voter role: <@&%s>, for playing at least %d submissions", cfg.VoterRole, cfg.minPlayed()) + "\n"
+	}
+	if !cfg.Deadline.IsZero() {
+		str += "- " + p.Sprintf("Deadline: %s", timestamp(cfg.Deadline)) + "\n"
+	}
+	if len(cfg.ExcludedVoters) > 0 {
+		str += "- " + p.Sprintf("Excluded voters: %s", mentions(cfg.ExcludedVoters)) + "\n"
+	}
+	if len(cfg.ExcludedContestants) > 0 {
+		str += "- " + p.Sprintf("Excluded contestants: %s", mentions(cfg.ExcludedContestants)) + "\n"
+	}
+	if m := es.missing(cfg.emojiNames()); len(m) > 0 && err == nil {
+		str += "\n" + p.Sprintf("⚠️ This server has no emoji named %s!", strings.Join(m, ", "))
+	}
+	return str
+}
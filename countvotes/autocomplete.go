@@ -0,0 +1,55 @@
+package countvotes
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+)
+
+const (
+	maxChoices          = 25 // most Discord accepts for autocomplete
+	maxChoiceNameLength = 100
+)
+
+// Autocomplete suggests the contests of the guild that have a stored configuration.
+func Autocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.Printer(i.Interaction)
+
+	var query string
+	for _, o := range i.ApplicationCommandData().Options {
+		if o.Focused && o.Name == optionContest {
+			query, _ = o.Value.(string)
+		}
+	}
+
+	cfgs, err := listConfigs(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, cfg := range cfgs {
+		name := cfg.Name
+		if c, err := s.State.Channel(cfg.Channel); err == nil {
+			name = strings.TrimSpace(name + " #" + c.Name)
+		} else if name == "" {
+			name = p.Sprintf("unnamed contest in channel %s", cfg.Channel)
+		}
+		if !strings.Contains(strings.ToLower(name), strings.ToLower(query)) {
+			continue
+		}
+		if r := []rune(name); len(r) > maxChoiceNameLength {
+			name = string(r[:maxChoiceNameLength-1]) + "…"
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: name, Value: cfg.Channel})
+		if len(choices) == maxChoices {
+			break
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	})
+}
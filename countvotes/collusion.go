@@ -0,0 +1,151 @@
+package countvotes
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/text/message"
+)
+
+const (
+	// ballots with fewer votes than this are too small to meaningfully compare
+	minSimilarBallotSize = 3
+	// Jaccard index above which two ballots are deemed suspiciously similar
+	similarBallotThreshold = 0.8
+	// in an ordinary contest many contestants vote for each other, and the results must fit in a single message
+	maxReportedPatterns = 10
+)
+
+// Looks for coordinated voting that per-voter validation cannot see:
+// - contestants voting for each other's submissions
+// - groups of voters casting (nearly) identical ballots
+// - contestants only voting for submissions of those who voted for them in return
+// None of this is necessarily cheating, so it is only reported for organizers to review.
+// Similar ballots come first, then voters only voting for partners, then reciprocal votes, and only the first few are listed.
+func (con contest) suspiciousPatterns(pr *message.Printer) []string {
+	type vote struct {
+		post     int
+		category string
+	}
+
+	ballots := make(map[string]map[vote]bool)
+	votedFor := make(map[string]map[string]bool) // voter -> authors
+	for i, p := range con.posts {
+		for cat, voters := range p.reactions {
+			if cat == con.cfg.PlayedEmoji {
+				continue
+			}
+			for _, v := range voters {
+				if ballots[v] == nil {
+					ballots[v] = make(map[vote]bool)
+					votedFor[v] = make(map[string]bool)
+				}
+				ballots[v][vote{i, cat}] = true
+				if p.author != "" && p.author != v {
+					votedFor[v][p.author] = true
+				}
+			}
+		}
+	}
+
+	contestants := make(map[string]bool)
+	for _, p := range con.posts {
+		if p.author != "" {
+			contestants[p.author] = true
+		}
+	}
+
+	var reciprocal, onlyPartners, similar []string
+
+	partners := make(map[string][]string)
+	for a := range contestants {
+		for b := range votedFor[a] {
+			if a < b && contestants[b] && votedFor[b][a] {
+				partners[a] = append(partners[a], b)
+				partners[b] = append(partners[b], a)
+				reciprocal = append(reciprocal, pr.Sprintf("%s and %s voted for each other's submissions.", userMention(a), userMention(b)))
+			}
+		}
+	}
+
+	for c, ps := range partners {
+		if len(votedFor[c]) < 2 {
+			continue
+		}
+		only := true
+		for a := range votedFor[c] {
+			if !slices.Contains(ps, a) {
+				only = false
+				break
+			}
+		}
+		if only {
+			slices.Sort(ps)
+			onlyPartners = append(onlyPartners, pr.Sprintf("%s only voted for submissions of contestants who voted for them in return: %s.", userMention(c), mentions(ps)))
+		}
+	}
+
+	var voters []string
+	for v, b := range ballots {
+		if len(b) >= minSimilarBallotSize {
+			voters = append(voters, v)
+		}
+	}
+	slices.Sort(voters)
+
+	// union-find, so that chains of similar ballots end up reported as a single group
+	group := make(map[string]string)
+	var find func(v string) string
+	find = func(v string) string {
+		if g, ok := group[v]; ok && g != v {
+			g = find(g)
+			group[v] = g
+			return g
+		}
+		return v
+	}
+	for i, a := range voters {
+		for _, b := range voters[i+1:] {
+			inter := 0
+			for v := range ballots[a] {
+				if ballots[b][v] {
+					inter++
+				}
+			}
+			union := len(ballots[a]) + len(ballots[b]) - inter
+			if float64(inter)/float64(union) >= similarBallotThreshold {
+				group[find(b)] = find(a)
+			}
+		}
+	}
+	groups := make(map[string][]string)
+	for v := range group {
+		g := find(v)
+		groups[g] = append(groups[g], v)
+	}
+	for g, members := range groups {
+		if !slices.Contains(members, g) {
+			members = append(members, g)
+		}
+		slices.Sort(members)
+		similar = append(similar, pr.Sprintf("%s cast nearly identical ballots.", mentions(members)))
+	}
+
+	var patterns []string
+	for _, ps := range [][]string{similar, onlyPartners, reciprocal} {
+		slices.Sort(ps)
+		patterns = append(patterns, ps...)
+	}
+	if l := len(patterns); l > maxReportedPatterns {
+		patterns = append(patterns[:maxReportedPatterns], pr.Sprintf("...and %d more patterns", l-maxReportedPatterns))
+	}
+	return patterns
+}
+
+func mentions(users []string) string {
+	var m []string
+	for _, u := range users {
+		m = append(m, userMention(u))
+	}
+	return strings.Join(m, ", ")
+}
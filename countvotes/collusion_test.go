@@ -0,0 +1,103 @@
+package countvotes
+
+import (
+	"slices"
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestSuspiciousPatterns(t *testing.T) {
+	type vote struct {
+		post     int
+		category string
+	}
+	tests := []struct {
+		name    string
+		authors []string // of each submission
+		ballots map[string][]vote
+		want    []string
+	}{
+		{
+			name:    "identical ballots",
+			authors: []string{"", "", ""},
+			ballots: map[string][]vote{
+				"x": {{0, "m"}, {1, "s"}, {2, "s"}},
+				"y": {{0, "m"}, {1, "s"}, {2, "s"}},
+				"z": {{1, "m"}, {0, "s"}, {2, "s"}},
+			},
+			want: []string{"<@x>, <@y> cast nearly identical ballots."},
+		},
+		{
+			name:    "too small to compare",
+			authors: []string{"", ""},
+			ballots: map[string][]vote{
+				"x": {{0, "m"}, {1, "s"}},
+				"y": {{0, "m"}, {1, "s"}},
+			},
+		},
+		{
+			name:    "chain of similar ballots",
+			authors: []string{"", "", "", ""},
+			ballots: map[string][]vote{
+				// x and z are not similar enough, but both are to y
+				"x": {{0, "m"}, {0, "s"}, {1, "s"}, {2, "s"}, {3, "s"}},
+				"y": {{0, "m"}, {0, "s"}, {1, "s"}, {2, "s"}, {3, "s"}, {1, "m"}},
+				"z": {{0, "m"}, {0, "s"}, {1, "s"}, {2, "s"}, {3, "s"}, {1, "m"}, {2, "m"}},
+			},
+			want: []string{"<@x>, <@y>, <@z> cast nearly identical ballots."},
+		},
+		{
+			name:    "separate groups",
+			authors: []string{"", "", "", "", "", ""},
+			ballots: map[string][]vote{
+				"u": {{0, "m"}, {1, "s"}, {2, "s"}},
+				"w": {{0, "m"}, {1, "s"}, {2, "s"}},
+				"x": {{3, "m"}, {4, "s"}, {5, "s"}},
+				"y": {{3, "m"}, {4, "s"}, {5, "s"}},
+			},
+			want: []string{"<@u>, <@w> cast nearly identical ballots.", "<@x>, <@y> cast nearly identical ballots."},
+		},
+		{
+			name:    "reciprocal votes",
+			authors: []string{"a", "b", "c"},
+			ballots: map[string][]vote{
+				"a": {{1, "m"}},
+				"b": {{0, "m"}, {2, "s"}},
+				"c": {{0, "m"}},
+			},
+			want: []string{"<@a> and <@b> voted for each other's submissions."},
+		},
+		{
+			name:    "only voting for partners",
+			authors: []string{"a", "b", "c"},
+			ballots: map[string][]vote{
+				"a": {{1, "m"}, {2, "s"}},
+				"b": {{0, "m"}},
+				"c": {{0, "m"}},
+			},
+			want: []string{
+				"<@a> only voted for submissions of contestants who voted for them in return: <@b>, <@c>.",
+				"<@a> and <@b> voted for each other's submissions.",
+				"<@a> and <@c> voted for each other's submissions.",
+			},
+		},
+	}
+	p := message.NewPrinter(language.English)
+	for _, tt := range tests {
+		con := contest{cfg: contestConfig{PlayedEmoji: "p", MainEmoji: "m", SecondaryEmojis: []string{"s"}}}
+		for i, a := range tt.authors {
+			con.posts = append(con.posts, &post{id: string(rune('0' + i)), author: a, reactions: make(map[string][]string)})
+		}
+		for voter, votes := range tt.ballots {
+			for _, v := range votes {
+				r := con.posts[v.post].reactions
+				r[v.category] = append(r[v.category], voter)
+			}
+		}
+		if got := con.suspiciousPatterns(p); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
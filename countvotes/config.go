@@ -0,0 +1,198 @@
+package countvotes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/itizir/hrv/store"
+)
+
+const (
+	configCollection = "contests"
+
+	defaultMaxVotesPerPost = 2
+
+	defaultMainPlaces        = 1
+	defaultSecondaryPlaces   = 1
+	defaultHonorableMentions = 0
+	maxPlaces                = 10
+)
+
+// contestConfig holds the settings of a contest, persisted per guild and contest channel.
+type contestConfig struct {
+	GuildID string `json:"guild_id"`
+	Channel string `json:"channel"`
+	Name    string `json:"name,omitempty"`
+
+	PlayedEmoji     string   `json:"played_emoji"`
+	MainEmoji       string   `json:"main_emoji"`
+	SecondaryEmojis []string `json:"secondary_emojis"`
+
+	// max 'secondary' votes a voter may give a single submission
+	MaxVotesPerPost int `json:"max_votes_per_post"`
+	// results are not revealed before the deadline, if set
+	Deadline time.Time `json:"deadline,omitempty"`
+
+	// places awarded in the main category and in each other category, at least one.
+	// Honorable mentions come after all places.
+	MainPlaces        int `json:"main_places,omitempty"`
+	SecondaryPlaces   int `json:"secondary_places,omitempty"`
+	HonorableMentions int `json:"honorable_mentions,omitempty"`
+	// decide each category on its own, letting a submission win several
+	MultipleWins bool `json:"multiple_wins,omitempty"`
+
+	// voters who played at least that many submissions, without breaking any rule, are eligible
+	// for the voter role. zero means a single one.
+	MinPlayed int    `json:"min_played,omitempty"`
+	VoterRole string `json:"voter_role,omitempty"`
+
+	// the category votes of voters who played fewer of the submissions they could vote for,
+	// as a percentage or a number, whichever is more, are dropped
+	MinCoveragePercent int `json:"min_coverage_percent,omitempty"`
+	MinCoverageCount   int `json:"min_coverage_count,omitempty"`
+
+	// standing exclusions, pre-selected every time votes are counted
+	ExcludedVoters      []string `json:"excluded_voters,omitempty"`
+	ExcludedContestants []string `json:"excluded_contestants,omitempty"`
+}
+
+// defaultConfig is what contests without a stored configuration use.
+func defaultConfig(guildID, channel string) contestConfig {
+	return contestConfig{
+		GuildID:         guildID,
+		Channel:         channel,
+		PlayedEmoji:     emojiPlayed,
+		MainEmoji:       emojiMain,
+		SecondaryEmojis: slices.Clone(emojiSecondary),
+		MaxVotesPerPost: defaultMaxVotesPerPost,
+
+		MainPlaces:        defaultMainPlaces,
+		SecondaryPlaces:   defaultSecondaryPlaces,
+		HonorableMentions: defaultHonorableMentions,
+	}
+}
+
+// categories returns the voting categories, main category first.
+func (cfg contestConfig) categories() []string {
+	return append([]string{cfg.MainEmoji}, cfg.SecondaryEmojis...)
+}
+
+// isContestEmoji reports whether reactions with the emoji matter for the contest.
+func (cfg contestConfig) isContestEmoji(name string) bool {
+	return name == cfg.PlayedEmoji || slices.Contains(cfg.categories(), name)
+}
+
+// places returns the number of places awarded in the category.
+func (cfg contestConfig) places(category string) int {
+	if category == cfg.MainEmoji {
+		return max(cfg.MainPlaces, 1)
+	}
+	return max(cfg.SecondaryPlaces, 1)
+}
+
+func (cfg contestConfig) minPlayed() int {
+	return max(cfg.MinPlayed, 1)
+}
+
+func (cfg contestConfig) emojiNames() []string {
+	return append([]string{cfg.PlayedEmoji}, cfg.categories()...)
+}
+
+func (cfg contestConfig) validate() error {
+	if cfg.Channel == "" {
+		return errors.New("missing contest channel")
+	}
+	names := cfg.emojiNames()
+	if slices.Contains(names, "") {
+		return errors.New("emojis must not be empty")
+	}
+	if len(cfg.SecondaryEmojis) == 0 {
+		return errors.New("need at least one secondary category")
+	}
+	for i, n := range names {
+		if slices.Contains(names[i+1:], n) {
+			return errors.New("emojis must all be different")
+		}
+	}
+	if cfg.MaxVotesPerPost < 1 {
+		return errors.New("max votes per submission must be at least 1")
+	}
+	if cfg.MainPlaces < 0 || cfg.SecondaryPlaces < 0 || cfg.MainPlaces > maxPlaces || cfg.SecondaryPlaces > maxPlaces {
+		return fmt.Errorf("places per category must be between 1 and %d", maxPlaces)
+	}
+	if cfg.MinCoveragePercent < 0 || cfg.MinCoveragePercent > 100 {
+		return errors.New("min coverage must be a percentage between 0 and 100")
+	}
+	if cfg.MinCoverageCount < 0 {
+		return errors.New("min coverage must not be negative")
+	}
+	if cfg.MinPlayed < 0 {
+		return errors.New("min played submissions must not be negative")
+	}
+	if cfg.HonorableMentions < 0 || cfg.HonorableMentions > maxPlaces {
+		return fmt.Errorf("honorable mentions must be between 0 and %d", maxPlaces)
+	}
+	return nil
+}
+
+func configCollectionOf(guildID string) string {
+	return store.Collection(configCollection, guildID)
+}
+
+// loadConfig returns the stored configuration of the contest, or the default one if there is none.
+func loadConfig(guildID, channel string) (contestConfig, bool, error) {
+	cfg := defaultConfig(guildID, channel)
+	ok, err := store.Get(configCollectionOf(guildID), channel, &cfg)
+	return cfg, ok, err
+}
+
+func saveConfig(cfg contestConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	return store.Put(configCollectionOf(cfg.GuildID), cfg.Channel, cfg)
+}
+
+func deleteConfig(guildID, channel string) error {
+	return store.Delete(configCollectionOf(guildID), channel)
+}
+
+func listConfigs(guildID string) ([]contestConfig, error) {
+	keys, err := store.Keys(configCollectionOf(guildID))
+	if err != nil {
+		return nil, err
+	}
+	var cfgs []contestConfig
+	for _, k := range keys {
+		cfg, ok, err := loadConfig(guildID, k)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cfgs = append(cfgs, cfg)
+		}
+	}
+	return cfgs, nil
+}
+
+// parseEmojiName accepts plain names as well as `:name:` and `<:name:id>` forms, as pasted from Discord.
+func parseEmojiName(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "<"), ">")
+	s = strings.TrimPrefix(s, "a:")
+	s = strings.Trim(s, ":")
+	name, _, _ := strings.Cut(s, ":")
+	return name
+}
+
+func parseDeadline(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid deadline: use e.g. 2024-05-31 or 2024-05-31 18:00 (UTC)")
+}
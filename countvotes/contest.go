@@ -3,10 +3,14 @@ package countvotes
 import (
 	"slices"
 	"strings"
+
+	"golang.org/x/text/message"
 )
 
 type contest struct {
-	posts []*post
+	posts  []*post
+	emojis emojiSet
+	cfg    contestConfig
 }
 
 // Prioritise breaking ambiguities by first considering main vote, then by overall least- to most-given votes.
@@ -19,7 +23,7 @@ func (con contest) orderedCategories() []string {
 		}
 	}
 
-	categories := append([]string{emojiMain}, emojiSecondary...)
+	categories := con.cfg.categories()
 	slices.SortStableFunc(categories[1:], func(a, b string) int {
 		return counts[a] - counts[b]
 	})
@@ -32,55 +36,93 @@ func (con contest) orderedCategories() []string {
 // giving the win of the tied category to the submission not eligible for another win.
 // If this is still not enough, attempt to break ties by including number of plays and other votes,
 // as described in `postCmp`.
-func (con contest) winners() []string {
-	if len(con.posts) == 0 {
-		return nil
+// As a last resort, a tie that none of the above can break goes to the earliest submission: one category at a time,
+// so that the usual rules get another chance at the remaining categories. Posts are expected sorted by submission time.
+// Places are awarded one after the other, first places in all categories, then second places, and so on:
+// a submission only ever gets one place, in one category. Unless the contest allows multiple wins,
+// in which case each category is decided on its own.
+// Picking winners marks the posts with the category and place they won, resetting any earlier marks.
+func (con contest) pickWinners() picked {
+	for _, p := range con.posts {
+		p.awards = nil
+	}
+
+	res := picked{podium: make(map[string][]*post)}
+	if con.cfg.MultipleWins {
+		con.pickIndependently(&res)
+		res.mentions = con.honorableMentions()
+		return res
 	}
 
-	win := make(map[string]*post)
 	categories := con.orderedCategories()
+	for place := 1; ; place++ {
+		cats := slices.DeleteFunc(slices.Clone(categories), func(cat string) bool {
+			return con.cfg.places(cat) < place
+		})
+		if len(cats) == 0 {
+			break
+		}
+		con.pickPlace(cats, place, &res)
+	}
+	res.mentions = con.honorableMentions()
+	return res
+}
+
+// pickPlace awards the given place in each of the categories, main category first if included.
+func (con contest) pickPlace(categories []string, place int, res *picked) {
+	win := make(map[string]bool)
 	superficialTies := true
-	mainCategoryMaxVotes := 0
+	lastResort := false
 
 	for {
 		foundNewWinner := false
-		for i, cat := range categories {
-			if _, ok := win[cat]; ok {
+		for _, cat := range categories {
+			if win[cat] {
 				continue
 			}
 			candidates := slices.Clone(con.posts)
 			candidates = slices.DeleteFunc(candidates, func(c *post) bool {
-				return c.won != "" || c.numReact(cat) == 0
+				return len(c.awards) > 0 || c.numReact(cat) == 0
 			})
 			if len(candidates) == 0 {
 				continue
 			}
-			slices.SortStableFunc(candidates, postCmp(cat))
+			slices.SortStableFunc(candidates, con.postCmp(cat))
 
 			numTied := 0
 			maxVotes := candidates[0].numReact(cat)
-			if i == 0 && mainCategoryMaxVotes == 0 {
-				mainCategoryMaxVotes = maxVotes
+			isMain := cat == con.cfg.MainEmoji
+			if isMain && place == 1 && res.mainCategoryMaxVotes == 0 {
+				res.mainCategoryMaxVotes = maxVotes
 			}
 			for _, c := range candidates[1:] {
 				// always consider tie-breakers for main category!
-				if (i > 0 && superficialTies && c.numReact(cat) == maxVotes) || postCmp(cat)(candidates[0], c) == 0 {
+				if (!isMain && superficialTies && c.numReact(cat) == maxVotes) || con.postCmp(cat)(candidates[0], c) == 0 {
 					numTied++
 				} else {
 					break
 				}
 			}
 
-			if numTied == 0 {
-				candidates[0].won = cat
-				win[cat] = candidates[0]
+			if numTied == 0 || lastResort {
+				// stable sort: among fully tied candidates, the first one is the earliest submission
+				candidates[0].awards = []award{{category: cat, place: place, tieBroken: numTied > 0}}
+				res.podium[cat] = append(res.podium[cat], candidates[0])
+				win[cat] = true
 				foundNewWinner = true
+				if numTied > 0 {
+					lastResort = false
+					superficialTies = true
+					break
+				}
 			}
 		}
 
 		if !foundNewWinner {
 			if superficialTies {
 				superficialTies = false
+			} else if !lastResort {
+				lastResort = true
 			} else {
 				break
 			}
@@ -89,41 +131,114 @@ func (con contest) winners() []string {
 			break
 		}
 	}
+}
+
+// pickIndependently awards the places of each category regardless of the others:
+// only ties no tie-breaker can settle are decided by submission time.
+func (con contest) pickIndependently(res *picked) {
+	for _, cat := range con.cfg.categories() {
+		candidates := slices.DeleteFunc(slices.Clone(con.posts), func(c *post) bool {
+			return c.numReact(cat) == 0
+		})
+		slices.SortStableFunc(candidates, con.postCmp(cat))
+		for k, c := range candidates[:min(len(candidates), con.cfg.places(cat))] {
+			// the last of several tied submissions lost the tie as much as the first one won it
+			tieBroken := (k+1 < len(candidates) && con.postCmp(cat)(c, candidates[k+1]) == 0) ||
+				(k > 0 && con.postCmp(cat)(candidates[k-1], c) == 0)
+			c.awards = append(c.awards, award{category: cat, place: k + 1, tieBroken: tieBroken})
+			res.podium[cat] = append(res.podium[cat], c)
+		}
+	}
+}
+
+// honorableMentions are the submissions that placed nowhere, but came closest in the main category.
+func (con contest) honorableMentions() []*post {
+	var mentions []*post
+	for _, p := range con.posts {
+		if len(p.awards) == 0 && p.numReact(con.cfg.MainEmoji) > 0 {
+			mentions = append(mentions, p)
+		}
+	}
+	slices.SortStableFunc(mentions, con.postCmp(con.cfg.MainEmoji))
+	return mentions[:min(len(mentions), con.cfg.HonorableMentions)]
+}
+
+// picked holds the places awarded in each category, best first, if any.
+type picked struct {
+	podium               map[string][]*post
+	mentions             []*post
+	mainCategoryMaxVotes int
+}
+
+// winners picks the winners and describes them, along with any ambiguity worth double-checking:
+// one entry per category with at least one place awarded, or per winning submission if they may win several.
+// Honorable mentions are described separately. complete tells whether all categories have a winner.
+// Ballots that do not count are dropped first.
+func (con contest) winners(p *message.Printer) (win []string, mentions []string, complete bool) {
+	con, _ = con.counted()
+	if len(con.posts) == 0 {
+		return nil, nil, false
+	}
+
+	res := con.pickWinners()
+	complete = true
+	for _, cat := range con.cfg.categories() {
+		if len(res.podium[cat]) == 0 {
+			complete = false
+		}
+	}
+	for _, m := range res.mentions {
+		mentions = append(mentions, m.format(p, con.emojis, con.cfg.MainEmoji))
+	}
+
+	if con.cfg.MultipleWins {
+		listed := make(map[*post]bool)
+		for _, cat := range con.cfg.categories() {
+			for _, w := range res.podium[cat] {
+				if !listed[w] {
+					listed[w] = true
+					win = append(win, w.format(p, con.emojis, con.cfg.MainEmoji))
+				}
+			}
+		}
+		return win, mentions, complete
+	}
 
-	var retval []string
-	for _, cat := range append([]string{emojiMain}, emojiSecondary...) {
-		if w, ok := win[cat]; ok {
-			res := w.String()
-			if cat == emojiMain && w.numReact(emojiMain) < mainCategoryMaxVotes {
-				res = "COULD NOT BREAK TIE! OVERALL WINNER HAS FEWER POINTS THAN OTHER SUBMISSIONS!\n" + res
+	for _, cat := range con.cfg.categories() {
+		var places []string
+		for k, w := range res.podium[cat] {
+			str := w.format(p, con.emojis, con.cfg.MainEmoji)
+			if cat == con.cfg.MainEmoji && k == 0 && w.numReact(cat) < res.mainCategoryMaxVotes {
+				str = p.Sprintf("COULD NOT BREAK TIE! OVERALL WINNER HAS FEWER POINTS THAN OTHER SUBMISSIONS!") + "\n" + str
 			}
 
 			var better, ties []string
-			for _, p := range con.posts {
-				if p.thread == w.thread {
+			for _, q := range con.posts {
+				if q.title == w.title || q.hasWon(cat) {
 					continue
 				}
-				if np, nw := p.numReact(cat), w.numReact(cat); np > nw {
-					if p.won == "" {
-						res += "... but shouldn't " + p.thread + " have won?!?"
+				if nq, nw := q.numReact(cat), w.numReact(cat); nq > nw {
+					if len(q.awards) == 0 {
+						str += p.Sprintf("... but shouldn't %s have won?!?", q.title)
 					} else {
-						better = append(better, p.thread)
+						better = append(better, q.title)
 					}
-				} else if np == nw {
-					ties = append(ties, p.thread)
+				} else if nq == nw {
+					ties = append(ties, q.title)
 				}
 			}
-			if len(better) > 0 || len(ties) > 0 {
-				if len(better) > 0 {
-					res += "\n   more votes, but won something else:\n    " + strings.Join(better, "\n    ")
-				}
-				if len(ties) > 0 {
-					res += "\n   tied number of votes:\n    " + strings.Join(ties, "\n    ")
-				}
+			if len(better) > 0 {
+				str += "\n   " + p.Sprintf("more votes, but won something else:") + "\n    " + strings.Join(better, "\n    ")
+			}
+			if len(ties) > 0 {
+				str += "\n   " + p.Sprintf("tied number of votes:") + "\n    " + strings.Join(ties, "\n    ")
 			}
 
-			retval = append(retval, res)
+			places = append(places, str)
+		}
+		if len(places) > 0 {
+			win = append(win, strings.Join(places, "\n  "))
 		}
 	}
-	return retval
+	return win, mentions, complete
 }
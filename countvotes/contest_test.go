@@ -0,0 +1,50 @@
+package countvotes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPickIndependentlyTies(t *testing.T) {
+	votes := func(n int) []string {
+		var users []string
+		for i := range n {
+			users = append(users, string(rune('a'+i)))
+		}
+		return users
+	}
+	tests := []struct {
+		name      string
+		votes     []int // main votes of each submission, in submission order
+		places    int
+		want      []string
+		tieBroken []bool
+	}{
+		{"no ties", []int{3, 2, 1}, 3, []string{"0", "1", "2"}, []bool{false, false, false}},
+		{"tie for first", []int{1, 1, 0}, 1, []string{"0"}, []bool{true}},
+		{"tie for last places", []int{2, 1, 1}, 3, []string{"0", "1", "2"}, []bool{false, true, true}},
+		{"tie beyond the places", []int{2, 1, 1}, 2, []string{"0", "1"}, []bool{false, true}},
+		{"later submission ahead", []int{1, 2, 1}, 3, []string{"1", "0", "2"}, []bool{false, true, true}},
+	}
+	for _, tt := range tests {
+		con := contest{cfg: contestConfig{PlayedEmoji: "p", MainEmoji: "m", MainPlaces: tt.places, MultipleWins: true}}
+		for i, n := range tt.votes {
+			con.posts = append(con.posts, &post{id: string(rune('0' + i)), reactions: map[string][]string{"m": votes(n)}})
+		}
+		res := con.pickWinners()
+
+		var got []string
+		var tieBroken []bool
+		for k, p := range res.podium["m"] {
+			got = append(got, p.id)
+			a, _ := p.award("m")
+			if a.place != k+1 {
+				t.Errorf("%s: %s placed %d, want %d", tt.name, p.id, a.place, k+1)
+			}
+			tieBroken = append(tieBroken, a.tieBroken)
+		}
+		if !slices.Equal(got, tt.want) || !slices.Equal(tieBroken, tt.tieBroken) {
+			t.Errorf("%s: got %v, tie broken %v, want %v, %v", tt.name, got, tieBroken, tt.want, tt.tieBroken)
+		}
+	}
+}
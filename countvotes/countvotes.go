@@ -3,15 +3,20 @@ package countvotes
 import (
 	"errors"
 	"fmt"
+	"log"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+	"golang.org/x/text/message"
 )
 
 const (
 	optionChannel = "channel"
+	optionContest = "contest"
 
 	selectExcludeVoters      = "exclude_voters"
 	selectExcludeContestants = "exclude_contestants"
@@ -21,6 +26,9 @@ const (
 	buttonCancel   = "cancel"
 
 	maxSelections = 25 // max allowed in selectors... eh, hopefully enough for our purpose here.
+
+	progressSubmissions  = "(fetched %d/%d submissions)"
+	progressParticipants = "(checked %d/%d participants)"
 )
 
 var (
@@ -28,13 +36,17 @@ var (
 		Name:        "countvotes",
 		Description: "Count votes and determine contest winners",
 		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         optionContest,
+				Description:  "Configured contest",
+				Autocomplete: true,
+			},
 			{
 				Type:         discordgo.ApplicationCommandOptionChannel,
 				Name:         optionChannel,
-				Description:  "Forum channel of the contest",
-				Required:     true,
-				Autocomplete: true,
-				ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildForum},
+				Description:  "Forum or text channel of the contest, if not configured",
+				ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildForum, discordgo.ChannelTypeGuildText},
 			},
 		},
 	}
@@ -48,30 +60,50 @@ type options struct {
 }
 
 func Handle(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	// the ephemeral controls are for the caller, while the results are for everyone to see
+	p := i18n.Printer(i.Interaction)
+	gp := i18n.GuildPrinter(i.Interaction)
+
 	if i.Type == discordgo.InteractionApplicationCommand {
+		opts := commandOptions(i.ApplicationCommandData())
+		if opts.channel == "" {
+			return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: p.Sprintf("Pick a contest or a channel."),
+					Flags:   discordgo.MessageFlagsEphemeral,
+				},
+			})
+		}
+
+		content := gp.Sprintf("Fetching list of participants, and then waiting for caller input. Be patient!")
 		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{Content: "Fetching list of participants, and then waiting for caller input. Be patient!"},
+			Data: &discordgo.InteractionResponseData{Content: content},
 		})
 		if err != nil {
 			return err
 		}
 
-		opts := commandOptions(i.ApplicationCommandData())
-		unknown, err := fetchUnknownUsers(s, i.GuildID, opts.channel)
+		cfg, _, err := loadConfig(i.GuildID, opts.channel)
 		if err != nil {
-			c := fmt.Sprintf("Failed to fetch data: %v.", err)
+			c := gp.Sprintf("Failed to load contest configuration: %v.", err)
 			_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &c})
 			return err
 		}
-		if len(unknown) > maxSelections {
-			unknown = unknown[:maxSelections]
+		unknown, err := fetchUnknownUsers(s, cfg,
+			progressReporter(s, i.Interaction, gp, content, progressSubmissions),
+			progressReporter(s, i.Interaction, gp, content, progressParticipants))
+		if err != nil {
+			c := gp.Sprintf("Failed to fetch data: %v.", err)
+			_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &c})
+			return err
 		}
-		opts.excludedVoters = append(opts.excludedVoters, unknown...)
-		opts.excludedContestants = append(opts.excludedContestants, unknown...)
+		opts.excludedVoters = withUnknown(cfg.ExcludedVoters, unknown)
+		opts.excludedContestants = withUnknown(cfg.ExcludedContestants, unknown)
 
 		_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
-			Components: components(opts),
+			Components: components(p, opts),
 			Flags:      discordgo.MessageFlagsEphemeral,
 		})
 		return err
@@ -92,10 +124,10 @@ func Handle(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseUpdateMessage,
 			Data: &discordgo.InteractionResponseData{
-				Components: components(opts),
+				Components: components(p, opts),
 			},
 		})
-	case buttonValidate, buttonResults, buttonCancel:
+	case buttonValidate, buttonResults, buttonReveal, buttonRevealNext, buttonReward, buttonCancel:
 		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{Type: discordgo.InteractionResponseDeferredMessageUpdate})
 		if err != nil {
 			return err
@@ -103,6 +135,9 @@ func Handle(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 		if msg.MessageReference == nil {
 			return errors.New("nil message reference")
 		}
+		if action == buttonRevealNext {
+			return revealNext(s, i, p, opts.channel)
+		}
 	case "":
 		return errors.New("no action")
 	default:
@@ -115,7 +150,7 @@ func Handle(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 			return err
 		}
 	} else {
-		content := "Fetching data and determining results..."
+		content := p.Sprintf("Fetching data and determining results...")
 		_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 			Content:    &content,
 			Components: &([]discordgo.MessageComponent{}),
@@ -123,22 +158,80 @@ func Handle(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 		if err != nil {
 			return err
 		}
-		resp := determineResults(s, i.GuildID, opts)
-		_, err = s.ChannelMessageEdit(msg.MessageReference.ChannelID, msg.MessageReference.MessageID, resp)
+		cfg, _, err := loadConfig(i.GuildID, opts.channel)
 		if err != nil {
 			return err
 		}
+		progress := progressReporter(s, i.Interaction, p, content, progressSubmissions)
+		if action == buttonReveal {
+			intro, r, ok := prepareReveal(s, gp, cfg, opts, progress)
+			_, err = s.ChannelMessageEdit(msg.MessageReference.ChannelID, msg.MessageReference.MessageID, intro)
+			if err != nil {
+				return err
+			}
+			if ok {
+				// keep the controls around until everything is revealed
+				content = p.Sprintf("Reveal the results whenever ready:")
+				controls := revealControls(p, opts.channel, r)
+				_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+					Content:    &content,
+					Components: &controls,
+				})
+				return err
+			}
+		} else if action == buttonReward {
+			resp := rewardVoters(s, gp, cfg, opts, progress)
+			_, err = s.ChannelMessageEdit(msg.MessageReference.ChannelID, msg.MessageReference.MessageID, resp)
+			if err != nil {
+				return err
+			}
+		} else {
+			resp := determineResults(s, gp, cfg, opts, progress)
+			_, err = s.ChannelMessageEdit(msg.MessageReference.ChannelID, msg.MessageReference.MessageID, resp)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return s.InteractionResponseDelete(i.Interaction)
 }
 
-func determineResults(s *discordgo.Session, guildID string, opts options) string {
-	resp := ""
+func determineResults(s *discordgo.Session, p *message.Printer, cfg contestConfig, opts options, progress func(done, total int)) string {
+	con, excludedVoters, resp, ok := prepareContest(s, p, cfg, opts, progress)
+	if !ok {
+		return resp
+	}
+	snap := newSnapshot(con, opts)
+	resp = con.evaluate(p, resp, excludedVoters, opts.validateOnly)
+
+	prev, hasPrev, err := loadLastRun(cfg.GuildID, cfg.Channel)
+	if err != nil {
+		log.Printf("failed to load previous run of %v: %v", cfg.Channel, err)
+	} else if hasPrev {
+		resp += "\n\n" + con.diff(p, prev, excludedVoters, opts.validateOnly)
+	}
+	if err := saveLastRun(snap); err != nil {
+		log.Printf("failed to save run of %v: %v", cfg.Channel, err)
+	}
+
+	if !opts.validateOnly {
+		resp += "\n\n" + recordSnapshot(p, snap)
+	}
+	return resp
+}
 
+// prepareContest fetches everything results are determined from. It returns the start of the response,
+// listing exclusions, or if not ok, explaining what went wrong.
+func prepareContest(s *discordgo.Session, p *message.Printer, cfg contestConfig, opts options, progress func(done, total int)) (contest, map[string]bool, string, bool) {
+	if !opts.validateOnly && time.Now().Before(cfg.Deadline) {
+		return contest{}, nil, p.Sprintf("Voting in <#%s> is open until %s: results can't be revealed before then!", opts.channel, timestamp(cfg.Deadline)), false
+	}
+
+	resp := ""
 	excludedVoters := make(map[string]bool)
 	if len(opts.excludedVoters) > 0 {
-		resp += "Ignored voters: "
+		resp += p.Sprintf("Ignored voters: ")
 	}
 	for i, u := range opts.excludedVoters {
 		resp += userMention(u)
@@ -152,7 +245,7 @@ func determineResults(s *discordgo.Session, guildID string, opts options) string
 
 	excludedContestants := make(map[string]bool)
 	if len(opts.excludedContestants) > 0 {
-		resp += "Ignored contestants: "
+		resp += p.Sprintf("Ignored contestants: ")
 	}
 	for i, u := range opts.excludedContestants {
 		resp += userMention(u)
@@ -168,53 +261,103 @@ func determineResults(s *discordgo.Session, guildID string, opts options) string
 		resp += "\n"
 	}
 
-	posts, err := fetchPosts(s, guildID, opts.channel, excludedVoters, excludedContestants)
+	posts, err := fetchPosts(s, cfg, excludedVoters, excludedContestants, progress)
+	if err != nil {
+		return contest{}, nil, resp + p.Sprintf("Oops! Failed to get the data from <#%v>: %v.", opts.channel, err), false
+	}
+
+	es, err := emojis.resolve(s, cfg.GuildID)
 	if err != nil {
-		return fmt.Sprintf("%sOops! Failed to get the data from <#%v>: %v.", resp, opts.channel, err)
+		return contest{}, nil, resp + p.Sprintf("Oops! Failed to get the emojis of the server: %v.", err), false
 	}
 
-	con := contest{posts: posts}
+	return contest{posts: posts, emojis: es, cfg: cfg}, excludedVoters, resp, true
+}
 
-	hasIrregularities := false
-	if irregularities := con.validate(excludedVoters); len(irregularities) > 0 {
-		hasIrregularities = true
-		resp += "Oh no! Found some irregularities:\n"
-		resp += "- " + strings.Join(irregularities, "\n- ") + "\n"
-		resp += "...so the results shouldn't be trusted. 😿\n\n"
+// recordSnapshot saves the snapshot of the data behind published results, and says where to find it.
+func recordSnapshot(p *message.Printer, snap snapshot) string {
+	h, err := saveSnapshot(snap)
+	if err != nil {
+		log.Printf("failed to save snapshot of %v: %v", snap.Config.Channel, err)
+		return p.Sprintf("⚠️ Failed to record a snapshot of the data behind these results: %v.", err)
 	}
+	return p.Sprintf("🔏 Snapshot of the data behind these results: `%s`", h)
+}
 
-	win := con.winners()
-	if l := len(win); l == 0 {
-		resp += fmt.Sprintf("Ooof! Could not determine _any_ winners in <#%v>!", opts.channel)
+// evaluate appends the validation report to resp and, unless validating only, the winners.
+func (con contest) evaluate(p *message.Printer, resp string, excludedVoters map[string]bool, validateOnly bool) string {
+	channel := con.cfg.Channel
+	report, hasIrregularities := con.report(p, excludedVoters)
+	resp += report
+
+	win, mentions, complete := con.winners(p)
+	if len(win) == 0 {
+		resp += p.Sprintf("Ooof! Could not determine _any_ winners in <#%v>!", channel)
 		return resp
-	} else if l < 1+len(emojiSecondary) {
+	} else if !complete {
 		hasIrregularities = true
-		resp += "Could not determine winners for all categories: undecidable ties, or too few eligible submissions. Sad. Well, anyway...\n\n"
+		resp += p.Sprintf("Could not determine winners for all categories: undecidable ties, or too few eligible submissions. Sad. Well, anyway...") + "\n\n"
 	}
 
-	if opts.validateOnly {
+	if validateOnly {
 		if hasIrregularities {
-			resp = fmt.Sprintf("Validating <#%s> without revealing results...\n\n%s", opts.channel, resp)
+			resp = p.Sprintf("Validating <#%s> without revealing results...", channel) + "\n\n" + resp
 		} else {
-			resp = fmt.Sprintf("No irregularities in <#%s>! 👏\n\n%s", opts.channel, resp)
+			resp = p.Sprintf("No irregularities in <#%s>! 👏", channel) + "\n\n" + resp
 		}
 	} else {
-		resp += fmt.Sprintf("🥁 Without further ado, the winners of <#%s>:\n", opts.channel)
+		resp += p.Sprintf("🥁 Without further ado, the winners of <#%s>:", channel) + "\n"
 		resp += "- " + strings.Join(win, "\n- ") + "\n"
-		resp += "Congratulations! 🎉"
+		if len(mentions) > 0 {
+			resp += p.Sprintf("🎖️ Honorable mentions:") + "\n"
+			resp += "- " + strings.Join(mentions, "\n- ") + "\n"
+		}
+		resp += p.Sprintf("Congratulations! 🎉")
 	}
 
 	return resp
 }
 
+// report describes irregularities, suspicious patterns and dropped ballots, if any, and counts eligible voters.
+// Only rewarding them lists them, as there may be too many to mention along with the results.
+func (con contest) report(p *message.Printer, excludedVoters map[string]bool) (resp string, hasIrregularities bool) {
+	if irregularities := con.validate(p, excludedVoters); len(irregularities) > 0 {
+		hasIrregularities = true
+		resp += p.Sprintf("Oh no! Found some irregularities:") + "\n"
+		resp += "- " + strings.Join(irregularities, "\n- ") + "\n"
+		resp += p.Sprintf("...so the results shouldn't be trusted. 😿") + "\n\n"
+	}
+
+	if patterns := con.suspiciousPatterns(p); len(patterns) > 0 {
+		resp += p.Sprintf("🔍 Suspicious patterns, for organizers to review:") + "\n"
+		resp += "- " + strings.Join(patterns, "\n- ") + "\n\n"
+	}
+
+	if _, dropped := con.counted(); len(dropped) > 0 {
+		resp += p.Sprintf("🚫 Dropped the ballots of %d voters who played too few submissions: %s", len(dropped), mentions(dropped)) + "\n\n"
+	}
+
+	if voters := con.eligibleVoters(excludedVoters); len(voters) > 0 {
+		resp += p.Sprintf("🗳️ %d voters played at least %d submissions without breaking any rule.", len(voters), con.cfg.minPlayed()) + "\n\n"
+	}
+	return resp, hasIrregularities
+}
+
 func commandOptions(data discordgo.ApplicationCommandInteractionData) options {
 	var opts options
+	var contest string
 	for _, o := range data.Options {
 		switch o.Name {
 		case optionChannel:
 			opts.channel, _ = o.Value.(string)
+		case optionContest:
+			contest, _ = o.Value.(string)
 		}
 	}
+	// the contest suggestions have the channel as value
+	if opts.channel == "" {
+		opts.channel = contest
+	}
 	return opts
 }
 
@@ -236,7 +379,7 @@ func messageInteractionUpdate(data discordgo.MessageComponentInteractionData, op
 	return action
 }
 
-func components(opts options) []discordgo.MessageComponent {
+func components(p *message.Printer, opts options) []discordgo.MessageComponent {
 	zero := 0
 
 	elementID := func(id string) string {
@@ -259,7 +402,7 @@ func components(opts options) []discordgo.MessageComponent {
 					CustomID:      elementID(selectExcludeVoters),
 					MinValues:     &zero,
 					MaxValues:     maxSelections,
-					Placeholder:   "Excluded Voters",
+					Placeholder:   p.Sprintf("Excluded Voters"),
 					DefaultValues: excludedVoters,
 				},
 			},
@@ -271,7 +414,7 @@ func components(opts options) []discordgo.MessageComponent {
 					CustomID:      elementID(selectExcludeContestants),
 					MinValues:     &zero,
 					MaxValues:     maxSelections,
-					Placeholder:   "Excluded Contestants",
+					Placeholder:   p.Sprintf("Excluded Contestants"),
 					DefaultValues: excludedContestants,
 				},
 			},
@@ -279,17 +422,27 @@ func components(opts options) []discordgo.MessageComponent {
 		discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				discordgo.Button{
-					Label:    "Validate Only",
+					Label:    p.Sprintf("Validate Only"),
 					Style:    discordgo.SuccessButton,
 					CustomID: elementID(buttonValidate),
 				},
 				discordgo.Button{
-					Label:    "Get Results",
+					Label:    p.Sprintf("Get Results"),
 					Style:    discordgo.PrimaryButton,
 					CustomID: elementID(buttonResults),
 				},
 				discordgo.Button{
-					Label:    "Cancel",
+					Label:    p.Sprintf("Reveal Step by Step"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: elementID(buttonReveal),
+				},
+				discordgo.Button{
+					Label:    p.Sprintf("Reward Voters"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: elementID(buttonReward),
+				},
+				discordgo.Button{
+					Label:    p.Sprintf("Cancel"),
 					Style:    discordgo.DangerButton,
 					CustomID: elementID(buttonCancel),
 				},
@@ -322,8 +475,9 @@ func fromDefaultValues(c []discordgo.MessageComponent) options {
 	return opts
 }
 
-func fetchUnknownUsers(s *discordgo.Session, guildID, channelID string) ([]string, error) {
-	p, err := fetchPosts(s, guildID, channelID, nil, nil)
+func fetchUnknownUsers(s *discordgo.Session, cfg contestConfig, postsProgress, usersProgress func(done, total int)) ([]string, error) {
+	guildID := cfg.GuildID
+	p, err := fetchPosts(s, cfg, nil, nil, postsProgress)
 	if err != nil {
 		return nil, err
 	}
@@ -337,35 +491,46 @@ func fetchUnknownUsers(s *discordgo.Session, guildID, channelID string) ([]strin
 			}
 		}
 	}
-
-	wg := sync.WaitGroup{}
-	wg.Add(len(uniqueUsers))
-	uc := make(chan string, len(uniqueUsers))
-	ec := make(chan error, len(uniqueUsers))
+	candidates := make([]string, 0, len(uniqueUsers))
 	for u := range uniqueUsers {
-		go func(u string) {
-			defer wg.Done()
-			if _, err := s.GuildMember(guildID, u); err != nil {
-				if e, ok := err.(*discordgo.RESTError); ok && e.Message.Code == discordgo.ErrCodeUnknownMember {
-					uc <- u
-				} else {
-					ec <- err
-				}
-			}
-		}(u)
+		candidates = append(candidates, u)
 	}
-	wg.Wait()
-	close(uc)
-	close(ec)
 
-	if err := <-ec; err != nil {
-		return nil, err
+	var (
+		mu    sync.Mutex
+		users []string
+	)
+	errs := forEachBounded(len(candidates), func(i int) error {
+		u := candidates[i]
+		if _, err := s.GuildMember(guildID, u); err != nil {
+			if e, ok := err.(*discordgo.RESTError); ok && e.Message != nil && e.Message.Code == discordgo.ErrCodeUnknownMember {
+				mu.Lock()
+				users = append(users, u)
+				mu.Unlock()
+			} else {
+				return fmt.Errorf("%s: %w", userMention(u), err)
+			}
+		}
+		return nil
+	}, usersProgress)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to check %d of %d participants:\n%w", len(errs), len(candidates), errors.Join(errs...))
 	}
 
-	var users []string
-	for u := range uc {
-		users = append(users, u)
-	}
 	slices.Sort(users)
 	return users, nil
 }
+
+// withUnknown adds users who left the guild to the standing exclusions, within the limits of the selectors.
+func withUnknown(excluded, unknown []string) []string {
+	users := slices.Clone(excluded)
+	for _, u := range unknown {
+		if !slices.Contains(users, u) {
+			users = append(users, u)
+		}
+	}
+	if len(users) > maxSelections {
+		users = users[:maxSelections]
+	}
+	return users
+}
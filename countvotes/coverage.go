@@ -0,0 +1,69 @@
+package countvotes
+
+import (
+	"maps"
+	"slices"
+)
+
+// requiredPlays is how many of the submissions a voter could vote for they must have played
+// for their category votes to count.
+func (cfg contestConfig) requiredPlays(eligible int) int {
+	fromPercent := (cfg.MinCoveragePercent*eligible + 99) / 100
+	return max(cfg.MinCoverageCount, fromPercent)
+}
+
+// counted returns the contest with the category votes of voters who did not play enough submissions dropped,
+// along with those voters, sorted. Played reactions are left untouched. The posts are copies:
+// the original contest stays as fetched.
+func (con contest) counted() (contest, []string) {
+	if con.cfg.MinCoveragePercent == 0 && con.cfg.MinCoverageCount == 0 {
+		return con, nil
+	}
+
+	played := make(map[string]int)
+	voters := make(map[string]bool)
+	for _, p := range con.posts {
+		for _, u := range p.reactions[con.cfg.PlayedEmoji] {
+			if u != p.author {
+				played[u]++
+			}
+		}
+		for _, cat := range con.cfg.categories() {
+			for _, u := range p.reactions[cat] {
+				voters[u] = true
+			}
+		}
+	}
+
+	dropped := make(map[string]bool)
+	var droppedVoters []string
+	for u := range voters {
+		eligible := 0
+		for _, p := range con.posts {
+			if p.author != u {
+				eligible++
+			}
+		}
+		if played[u] < con.cfg.requiredPlays(eligible) {
+			dropped[u] = true
+			droppedVoters = append(droppedVoters, u)
+		}
+	}
+	if len(dropped) == 0 {
+		return con, nil
+	}
+
+	filtered := con
+	filtered.posts = nil
+	for _, p := range con.posts {
+		q := *p
+		q.awards = nil
+		q.reactions = maps.Clone(p.reactions)
+		for _, cat := range con.cfg.categories() {
+			q.reactions[cat] = slices.DeleteFunc(slices.Clone(q.reactions[cat]), func(u string) bool { return dropped[u] })
+		}
+		filtered.posts = append(filtered.posts, &q)
+	}
+	slices.Sort(droppedVoters)
+	return filtered, droppedVoters
+}
@@ -0,0 +1,155 @@
+package countvotes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/itizir/hrv/store"
+	"golang.org/x/text/message"
+)
+
+const (
+	runsCollection = "runs"
+
+	// keep the diff from drowning the rest of the message
+	maxDiffLines = 15
+)
+
+// lastRun is the data of the previous evaluation of a contest, validation only or not.
+// Everything else, like irregularities or winners, is recomputed from it when needed:
+// evaluation is deterministic.
+type lastRun struct {
+	At       time.Time `json:"at"`
+	Snapshot snapshot  `json:"snapshot"`
+}
+
+func runsCollectionOf(guildID string) string {
+	return store.Collection(runsCollection, guildID)
+}
+
+func loadLastRun(guildID, channel string) (lastRun, bool, error) {
+	var run lastRun
+	ok, err := store.Get(runsCollectionOf(guildID), channel, &run)
+	return run, ok, err
+}
+
+func saveLastRun(snap snapshot) error {
+	return store.Put(runsCollectionOf(snap.Config.GuildID), snap.Config.Channel, lastRun{At: time.Now(), Snapshot: snap})
+}
+
+// diff describes what changed since the previous run: votes, irregularities, and winners, unless still secret.
+func (con contest) diff(p *message.Printer, prev lastRun, excludedVoters map[string]bool, validateOnly bool) string {
+	old := prev.Snapshot.contest(con.emojis)
+
+	var lines []string
+
+	oldPosts := make(map[string]*post)
+	for _, q := range old.posts {
+		oldPosts[q.id] = q
+	}
+	newPosts := make(map[string]bool)
+	for _, q := range con.posts {
+		newPosts[q.id] = true
+		o, ok := oldPosts[q.id]
+		if !ok {
+			lines = append(lines, p.Sprintf("%s: new submission", q.title))
+			continue
+		}
+		var changes []string
+		for _, e := range con.cfg.emojiNames() {
+			added, removed := setDiff(q.reactions[e], o.reactions[e])
+			if len(added) > 0 {
+				changes = append(changes, fmt.Sprintf("+%d %s (%s)", len(added), con.emojis.format(e), mentions(added)))
+			}
+			if len(removed) > 0 {
+				changes = append(changes, fmt.Sprintf("-%d %s (%s)", len(removed), con.emojis.format(e), mentions(removed)))
+			}
+		}
+		if len(changes) > 0 {
+			lines = append(lines, q.title+": "+strings.Join(changes, ", "))
+		}
+	}
+	for _, o := range old.posts {
+		if !newPosts[o.id] {
+			lines = append(lines, p.Sprintf("%s: submission gone", o.title))
+		}
+	}
+	if l := len(lines); l > maxDiffLines {
+		lines = append(lines[:maxDiffLines], p.Sprintf("...and %d more submissions with changes", l-maxDiffLines))
+	}
+
+	// compared as they are, not as described: a different wording, like another language or a retitled submission,
+	// is no change
+	oldIrregularities := old.irregularities(prev.Snapshot.excludedVoters())
+	newIrregularities := con.irregularities(excludedVoters)
+	var fixed, added []string
+	for _, irr := range oldIrregularities {
+		if !slices.ContainsFunc(newIrregularities, irr.same) {
+			fixed = append(fixed, p.Sprintf("✅ no longer: %s", old.formatIrregularity(p, irr)))
+		}
+	}
+	for _, irr := range newIrregularities {
+		if !slices.ContainsFunc(oldIrregularities, irr.same) {
+			added = append(added, p.Sprintf("🆕 %s", con.formatIrregularity(p, irr)))
+		}
+	}
+	slices.Sort(fixed)
+	slices.Sort(added)
+	lines = append(lines, fixed...)
+	lines = append(lines, added...)
+
+	oldCounted, _ := old.counted()
+	newCounted, _ := con.counted()
+	oldPodium, newPodium := oldCounted.pickWinners().podium, newCounted.pickWinners().podium
+	var changedWinners []string
+	for _, cat := range con.cfg.categories() {
+		if !slices.EqualFunc(oldPodium[cat], newPodium[cat], func(o, n *post) bool { return o.id == n.id }) {
+			changedWinners = append(changedWinners, cat)
+		}
+	}
+	if len(changedWinners) > 0 {
+		if validateOnly {
+			lines = append(lines, p.Sprintf("🏆 Some category winners changed, but that stays secret until the results are revealed."))
+		} else {
+			for _, cat := range changedWinners {
+				lines = append(lines, p.Sprintf("🏆 %s: %s → %s", con.emojis.format(cat), podiumTitles(p, oldPodium[cat]), podiumTitles(p, newPodium[cat])))
+			}
+		}
+	}
+
+	header := p.Sprintf("🔁 Changes since the previous count (%s):", timestamp(prev.At))
+	if len(lines) == 0 {
+		return header + " " + p.Sprintf("none.")
+	}
+	return header + "\n- " + strings.Join(lines, "\n- ")
+}
+
+func podiumTitles(p *message.Printer, podium []*post) string {
+	if len(podium) == 0 {
+		return p.Sprintf("_nobody_")
+	}
+	var titles []string
+	for _, q := range podium {
+		titles = append(titles, q.title)
+	}
+	return strings.Join(titles, ", ")
+}
+
+// setDiff returns the elements only in a, and those only in b, sorted.
+func setDiff(a, b []string) (onlyA, onlyB []string) {
+	for _, x := range a {
+		if !slices.Contains(b, x) {
+			onlyA = append(onlyA, x)
+		}
+	}
+	for _, x := range b {
+		if !slices.Contains(a, x) {
+			onlyB = append(onlyB, x)
+		}
+	}
+	slices.Sort(onlyA)
+	slices.Sort(onlyB)
+	return onlyA, onlyB
+}
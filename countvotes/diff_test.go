@@ -0,0 +1,45 @@
+package countvotes
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestDiffIrregularities(t *testing.T) {
+	cfg := contestConfig{PlayedEmoji: "p", MainEmoji: "m", MaxVotesPerPost: 2}
+	// voter v votes for both submissions, having played none
+	build := func(title string, selfVote bool) contest {
+		con := contest{cfg: cfg, posts: []*post{
+			{id: "1", title: title, author: "a", reactions: map[string][]string{"m": {"v"}, "p": {"a"}}},
+			{id: "2", title: "Second", author: "b", reactions: map[string][]string{"m": {"v"}, "p": {"b"}}},
+		}}
+		if selfVote {
+			con.posts[0].reactions["m"] = append(con.posts[0].reactions["m"], "a")
+		}
+		return con
+	}
+	prev := lastRun{Snapshot: newSnapshot(build("First", false), options{})}
+
+	tests := []struct {
+		name       string
+		con        contest
+		fixed, new int
+	}{
+		{"unchanged", build("First", false), 0, 0},
+		{"retitled submission", build("First (edited)", false), 0, 0},
+		{"new offense", build("First", true), 0, 1},
+	}
+	for _, tag := range []language.Tag{language.English, language.French} {
+		p := message.NewPrinter(tag)
+		for _, tt := range tests {
+			d := tt.con.diff(p, prev, nil, true)
+			fixed, added := strings.Count(d, "✅"), strings.Count(d, "🆕")
+			if fixed != tt.fixed || added != tt.new {
+				t.Errorf("%v: %s: %d fixed and %d new irregularities, want %d and %d:\n%s", tag, tt.name, fixed, added, tt.fixed, tt.new, d)
+			}
+		}
+	}
+}
@@ -1,12 +1,24 @@
 package countvotes
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"log"
+	"sync"
+	"time"
 
+	"github.com/bwmarrin/discordgo"
+)
+
+// defaults for contests without a stored configuration
 const (
 	emojiPlayed = "Genmat"
 	emojiMain   = "HRV"
 )
 
+const (
+	// guild emojis rarely change, but don't keep a stale set forever when running as a long-lived process
+	emojiCacheTTL = time.Hour
+)
+
 var (
 	emojiSecondary = []string{
 		"Fun",
@@ -15,11 +27,88 @@ var (
 		"Artistic",
 	}
 
-	knownEmojis = map[string]*discordgo.Emoji{}
+	emojis = &emojiResolver{guilds: make(map[string]cachedEmojis)}
 )
 
-func init() {
-	for _, s := range append(emojiSecondary, emojiPlayed, emojiMain) {
-		knownEmojis[s] = nil
+// emojiSet maps emoji names to the custom emojis of a given guild.
+// Missing entries simply mean the guild has no such emoji.
+type emojiSet map[string]*discordgo.Emoji
+
+// format returns the emoji as it should appear in a message, falling back to its plain name.
+func (es emojiSet) format(name string) string {
+	if e := es[name]; e != nil {
+		return e.MessageFormat()
+	}
+	return name
+}
+
+func (es emojiSet) missing(names []string) []string {
+	var m []string
+	for _, name := range names {
+		if es[name] == nil {
+			m = append(m, name)
+		}
+	}
+	return m
+}
+
+type cachedEmojis struct {
+	set       emojiSet
+	fetchedAt time.Time
+}
+
+// emojiResolver looks up the guild emojis once per guild, and is safe for concurrent use.
+type emojiResolver struct {
+	mu     sync.Mutex
+	guilds map[string]cachedEmojis
+}
+
+func (r *emojiResolver) resolve(s *discordgo.Session, guildID string) (emojiSet, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if c, ok := r.guilds[guildID]; ok && time.Since(c.fetchedAt) < emojiCacheTTL {
+		return c.set, nil
+	}
+
+	all, err := s.GuildEmojis(guildID)
+	if err != nil {
+		return nil, err
+	}
+	set := make(emojiSet)
+	for _, e := range all {
+		set[e.Name] = e
+	}
+	r.guilds[guildID] = cachedEmojis{set: set, fetchedAt: time.Now()}
+	return set, nil
+}
+
+// CheckEmojis logs a warning for every guild the bot is in that lacks some of the emojis
+// of the default contest configuration, or of its stored contest configurations.
+func CheckEmojis(s *discordgo.Session) {
+	guilds, err := s.UserGuilds(200, "", "", false)
+	if err != nil {
+		log.Println("failed to list guilds to check emojis:", err)
+		return
+	}
+	for _, g := range guilds {
+		set, err := emojis.resolve(s, g.ID)
+		if err != nil {
+			log.Printf("failed to fetch emojis of guild %v: %v", g.ID, err)
+			continue
+		}
+		if m := set.missing(defaultConfig(g.ID, "").emojiNames()); len(m) > 0 {
+			log.Printf("warning: guild %s (%v) is missing default contest emojis %v", g.Name, g.ID, m)
+		}
+		cfgs, err := listConfigs(g.ID)
+		if err != nil {
+			log.Printf("failed to load contest configurations of guild %v: %v", g.ID, err)
+			continue
+		}
+		for _, cfg := range cfgs {
+			if m := set.missing(cfg.emojiNames()); len(m) > 0 {
+				log.Printf("warning: guild %s (%v) is missing emojis %v of contest in %v", g.Name, g.ID, m, cfg.Channel)
+			}
+		}
 	}
 }
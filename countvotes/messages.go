@@ -0,0 +1,355 @@
+package countvotes
+
+import (
+	"github.com/itizir/hrv/i18n"
+	"golang.org/x/text/language"
+)
+
+func init() {
+	i18n.RegisterNames(language.French, map[string]string{
+		"countvotes":           "compter-votes",
+		"channel":              "salon",
+		"contest":              "concours",
+		"create":               "créer",
+		"show":                 "afficher",
+		"edit":                 "modifier",
+		"delete":               "supprimer",
+		"name":                 "nom",
+		"played":               "joué",
+		"main":                 "principal",
+		"secondary":            "secondaires",
+		"max_per_post":         "max_par_participation",
+		"deadline":             "échéance",
+		"exclude_voter":        "exclure_votant",
+		"exclude_contestant":   "exclure_participant",
+		"include":              "inclure",
+		"verify":               "vérifier",
+		"hash":                 "empreinte",
+		"places":               "places",
+		"secondary_places":     "places_secondaires",
+		"honorable_mentions":   "mentions_honorables",
+		"multiple_wins":        "victoires_multiples",
+		"min_played":           "min_joués",
+		"voter_role":           "rôle_votant",
+		"min_coverage_percent": "couverture_min_pourcent",
+		"min_coverage_count":   "couverture_min_nombre",
+	})
+	i18n.RegisterNames(language.German, map[string]string{
+		"countvotes":           "stimmen-zählen",
+		"channel":              "kanal",
+		"contest":              "wettbewerb",
+		"create":               "erstellen",
+		"show":                 "anzeigen",
+		"edit":                 "bearbeiten",
+		"delete":               "löschen",
+		"played":               "gespielt",
+		"main":                 "haupt",
+		"secondary":            "weitere",
+		"max_per_post":         "max_pro_einreichung",
+		"deadline":             "frist",
+		"exclude_voter":        "abstimmende_ausschließen",
+		"exclude_contestant":   "teilnehmende_ausschließen",
+		"include":              "einschließen",
+		"verify":               "prüfen",
+		"places":               "plätze",
+		"secondary_places":     "plätze_sekundär",
+		"honorable_mentions":   "lobende_erwähnungen",
+		"multiple_wins":        "mehrfachsiege",
+		"min_played":           "min_gespielt",
+		"voter_role":           "wählerrolle",
+		"min_coverage_percent": "mindestabdeckung_prozent",
+		"min_coverage_count":   "mindestabdeckung_anzahl",
+	})
+
+	i18n.Register(language.French, map[string]string{
+		"Count votes and determine contest winners": "Compter les votes et désigner les gagnants du concours",
+		"Forum or text channel of the contest":      "Forum ou salon textuel du concours",
+
+		"Manage contest configurations":                               "Gérer les configurations de concours",
+		"Configure a new contest":                                     "Configurer un nouveau concours",
+		"Show the configuration of a contest":                         "Afficher la configuration d'un concours",
+		"Edit the configuration of a contest":                         "Modifier la configuration d'un concours",
+		"Delete the configuration of a contest":                       "Supprimer la configuration d'un concours",
+		"Always ignore the votes of this member":                      "Toujours ignorer les votes de ce membre",
+		"Always ignore the submissions of this member":                "Toujours ignorer les participations de ce membre",
+		"Lift standing exclusions of this member":                     "Lever les exclusions permanentes de ce membre",
+		"Name of the contest":                                         "Nom du concours",
+		"Emoji marking a submission as played":                        "Émoji marquant une participation comme jouée",
+		"Emoji of the 'best overall' category":                        "Émoji de la catégorie « meilleur au général »",
+		"Emojis of the other categories, comma-separated":             "Émojis des autres catégories, séparés par des virgules",
+		"Max votes a voter may give a single submission":              "Nombre max de votes par votant pour une même participation",
+		"End of voting (UTC), e.g. 2024-05-31 18:00; 'none' to clear": "Fin des votes (UTC), p. ex. 2024-05-31 18:00 ; « none » pour retirer",
+
+		"<#%s> already has a contest configuration: use `/%s %s` instead.": "<#%s> a déjà une configuration de concours : utilisez plutôt `/%s %s`.",
+		"<#%s> has no contest configuration: use `/%s %s` first.":          "<#%s> n'a pas de configuration de concours : utilisez d'abord `/%s %s`.",
+		"<#%s> has no contest configuration, so the defaults apply:":       "<#%s> n'a pas de configuration de concours, les valeurs par défaut s'appliquent :",
+		"Invalid settings: %v.":                     "Paramètres invalides : %v.",
+		"Failed to save configuration: %v.":         "Échec de l'enregistrement de la configuration : %v.",
+		"Failed to load contest configuration: %v.": "Échec du chargement de la configuration du concours : %v.",
+		"Saved!": "Enregistré !",
+		"Check a results snapshot against its published hash, and recount from it": "Vérifier un instantané par rapport à son empreinte publiée et recompter",
+		"Snapshot hash published with the results":                                 "Empreinte de l'instantané publiée avec les résultats",
+		"Invalid hash.":                      "Empreinte invalide.",
+		"No snapshot stored with this hash.": "Aucun instantané enregistré avec cette empreinte.",
+		"⚠️ The stored snapshot does NOT match its hash: it was modified after the results were published!": "⚠️ L'instantané enregistré ne correspond PAS à son empreinte : il a été modifié après la publication des résultats !",
+		"✅ The stored snapshot matches its hash: %d submissions in <#%s>. Recounting from it...":            "✅ L'instantané enregistré correspond à son empreinte : %d participations dans <#%s>. Recomptage à partir de celui-ci...",
+		"⚠️ Failed to record a snapshot of the data behind these results: %v.":                              "⚠️ Impossible d'enregistrer un instantané des données derrière ces résultats : %v.",
+		"🔏 Snapshot of the data behind these results: `%s`":                                                 "🔏 Instantané des données derrière ces résultats : `%s`",
+		"OK!":                          "OK !",
+		"_unnamed_":                    "_sans nom_",
+		"**%s** in <#%s>":              "**%s** dans <#%s>",
+		"Played: %s":                   "Joué : %s",
+		"Best overall: %s":             "Meilleur au général : %s",
+		"Other categories: %s":         "Autres catégories : %s",
+		"Max votes per submission: %d": "Votes max par participation : %d",
+		"Deadline: %s":                 "Échéance : %s",
+		"Excluded voters: %s":          "Votants exclus : %s",
+		"Excluded contestants: %s":     "Participants exclus : %s",
+		"⚠️ This server has no emoji named %s!":                                    "⚠️ Ce serveur n'a pas d'émoji nommé %s !",
+		"Voting in <#%s> is open until %s: results can't be revealed before then!": "Les votes dans <#%s> sont ouverts jusqu'au %s : impossible de dévoiler les résultats avant !",
+
+		"Fetching list of participants, and then waiting for caller input. Be patient!": "Récupération de la liste des participants, puis attente des choix de l'organisateur. Patience !",
+		"Failed to fetch data: %v.":                "Échec de la récupération des données : %v.",
+		"Fetching data and determining results...": "Récupération des données et calcul des résultats...",
+		progressSubmissions:                        "(%d/%d participations récupérées)",
+		progressParticipants:                       "(%d/%d participants vérifiés)",
+		"Excluded Voters":                          "Votants exclus",
+		"Excluded Contestants":                     "Participants exclus",
+		"Validate Only":                            "Valider seulement",
+		"Get Results":                              "Résultats",
+		"Cancel":                                   "Annuler",
+
+		"Ignored voters: ":                                  "Votants ignorés : ",
+		"Ignored contestants: ":                             "Participants ignorés : ",
+		"Oops! Failed to get the data from <#%v>: %v.":      "Oups ! Impossible de récupérer les données de <#%v> : %v.",
+		"Oops! Failed to get the emojis of the server: %v.": "Oups ! Impossible de récupérer les émojis du serveur : %v.",
+		"Oh no! Found some irregularities:":                 "Oh non ! Des irrégularités ont été trouvées :",
+		"...so the results shouldn't be trusted. 😿":         "...les résultats ne sont donc pas fiables. 😿",
+		"Ooof! Could not determine _any_ winners in <#%v>!": "Aïe ! Impossible de désigner _le moindre_ gagnant dans <#%v> !",
+		"Could not determine winners for all categories: undecidable ties, or too few eligible submissions. Sad. Well, anyway...": "Impossible de désigner un gagnant dans chaque catégorie : égalités indécidables, ou trop peu de participations éligibles. Dommage. Bon, quoi qu'il en soit...",
+		"Validating <#%s> without revealing results...": "Validation de <#%s> sans dévoiler les résultats...",
+		"No irregularities in <#%s>! 👏":                 "Aucune irrégularité dans <#%s> ! 👏",
+		"🥁 Without further ado, the winners of <#%s>:":  "🥁 Sans plus attendre, les gagnants de <#%s> :",
+		"Congratulations! 🎉":                            "Félicitations ! 🎉",
+
+		"COULD NOT BREAK TIE! OVERALL WINNER HAS FEWER POINTS THAN OTHER SUBMISSIONS!": "IMPOSSIBLE DE DÉPARTAGER ! LE GAGNANT GÉNÉRAL A MOINS DE POINTS QUE D'AUTRES PARTICIPATIONS !",
+		"... but shouldn't %s have won?!?":                                             "... mais %s n'aurait-il pas dû gagner ?!?",
+		"more votes, but won something else:":                                          "plus de votes, mais a gagné autre chose :",
+		"tied number of votes:":                                                        "même nombre de votes :",
+		"(tie broken in favour of the earliest submission)":                            "(égalité départagée en faveur de la participation la plus ancienne)",
+
+		"Empty post":       "Publication vide",
+		"Best overall! ":   "Meilleur au général ! ",
+		"Most %s: ":        "Le plus %s : ",
+		"_unknown_":        "_inconnu_",
+		" — with %v %s":    " — avec %v %s",
+		" — with %v vote":  " — avec %v vote",
+		" — with %v votes": " — avec %v votes",
+
+		"made more than one submission":               "ont fait plus d'une participation",
+		"voted for their own submission":              "ont voté pour leur propre participation",
+		"gave out %d %s":                              "ont donné %d %s",
+		"gave out %d instead of max %d votes overall": "ont donné %d votes au lieu de %d au maximum",
+		"gave out too many votes to %s":               "ont donné trop de votes à %s",
+		"voted without reacting with %s on %s":        "ont voté sans réagir avec %s sur %s",
+		"seem to not have made any efforts in voting despite making a contest submission": "ne semblent pas avoir fait le moindre effort pour voter malgré leur participation au concours",
+		"_and_ %s": "_et_ %s",
+		"%s is on the naughty list! They %s! 🙀": "%s est sur la liste des vilains ! Iels %s ! 🙀",
+
+		"🔍 Suspicious patterns, for organizers to review:":                               "🔍 Comportements suspects, à examiner par l'organisation :",
+		"%s and %s voted for each other's submissions.":                                  "%s et %s ont voté pour la participation l'un de l'autre.",
+		"%s only voted for submissions of contestants who voted for them in return: %s.": "%s n'a voté que pour des participants qui ont voté pour ellui en retour : %s.",
+		"%s cast nearly identical ballots.":                                              "%s ont voté de façon quasi identique.",
+		"%s: new submission":                                                             "%s : nouvelle participation",
+		"%s: submission gone":                                                            "%s : participation disparue",
+		"...and %d more submissions with changes":                                        "...et %d autres participations modifiées",
+		"✅ no longer: %s":                                                                "✅ plus d'actualité : %s",
+		"🆕 %s":                                                                           "🆕 %s",
+		"🏆 Some category winners changed, but that stays secret until the results are revealed.": "🏆 Des gagnants de catégories ont changé, mais cela reste secret jusqu'à la révélation des résultats.",
+		"_nobody_":      "_personne_",
+		"🏆 %s: %s → %s": "🏆 %s : %s → %s",
+		"🔁 Changes since the previous count (%s):": "🔁 Changements depuis le précédent décompte (%s) :",
+		"none.":              "aucun.",
+		"Configured contest": "Concours configuré",
+		"Forum or text channel of the contest, if not configured":                        "Forum ou salon textuel du concours, s'il n'est pas configuré",
+		"Pick a contest or a channel.":                                                   "Choisissez un concours ou un salon.",
+		"unnamed contest in channel %s":                                                  "concours sans nom dans le salon %s",
+		"Places awarded in the 'best overall' category":                                  "Places attribuées dans la catégorie « meilleur au général »",
+		"Places awarded in each of the other categories":                                 "Places attribuées dans chacune des autres catégories",
+		"Number of honorable mentions for submissions that just missed":                  "Nombre de mentions honorables pour les participations qui ont failli gagner",
+		"Places: %d overall, %d in each other category":                                  "Places : %d au général, %d dans chaque autre catégorie",
+		"Honorable mentions: %d":                                                         "Mentions honorables : %d",
+		"#%d overall: ":                                                                  "N°%d au général : ",
+		"#%d in %s: ":                                                                    "N°%d en %s : ",
+		"🎖️ Honorable mentions:":                                                         "🎖️ Mentions honorables :",
+		"Let a submission win several categories":                                        "Permettre à une participation de gagner plusieurs catégories",
+		"A submission may win several categories":                                        "Une participation peut gagner plusieurs catégories",
+		"Reveal Step by Step":                                                            "Révéler pas à pas",
+		"Reveal the results whenever ready:":                                             "Révélez les résultats quand vous êtes prêt :",
+		"Reveal Next (%d/%d)":                                                            "Révéler la suite (%d/%d)",
+		"Nothing left to reveal.":                                                        "Plus rien à révéler.",
+		"Failed to save the results to reveal: %v.":                                      "Échec de l'enregistrement des résultats à révéler : %v.",
+		"🥁 The winners of <#%s> will now be revealed, one category at a time!":           "🥁 Les gagnants de <#%s> vont maintenant être révélés, une catégorie à la fois !",
+		"🥁 And in %s...":                                                                 "🥁 Et en %s...",
+		"🥁 And finally, best overall...":                                                 "🥁 Et enfin, le meilleur au général...",
+		"Submissions voters must have played to be rewarded":                             "Participations que les votants doivent avoir jouées pour être récompensés",
+		"Role granted to voters who took part properly":                                  "Rôle accordé aux votants ayant participé dans les règles",
+		"Voter role: <@&%s>, for playing at least %d submissions":                        "Rôle de votant : <@&%s>, pour avoir joué au moins %d participations",
+		"Reward Voters":                                                                  "Récompenser les votants",
+		"No voter role configured for <#%s>: set one with `/%s %s`.":                     "Aucun rôle de votant configuré pour <#%s> : définissez-en un avec `/%s %s`.",
+		"No voter in <#%s> played at least %d submissions without breaking any rule.":    "Aucun votant de <#%s> n'a joué au moins %d participations sans enfreindre de règle.",
+		"🗳️ Thanks for voting! <@&%s> granted to %s.":                                    "🗳️ Merci d'avoir voté ! <@&%s> accordé à %s.",
+		"⚠️ Failed to grant the role to %d of %d voters:":                                "⚠️ Échec de l'attribution du rôle à %d votants sur %d :",
+		"🗳️ %d voters played at least %d submissions without breaking any rule.":         "🗳️ %d votants ont joué au moins %d participations sans enfreindre de règle.",
+		"Percentage of the submissions voters must have played for their votes to count": "Pourcentage des participations que les votants doivent avoir jouées pour que leurs votes comptent",
+		"Number of submissions voters must have played for their votes to count":         "Nombre de participations que les votants doivent avoir jouées pour que leurs votes comptent",
+		"Votes only count for voters who played at least %d%% or %d of the submissions, whichever is more": "Les votes ne comptent que pour les votants ayant joué au moins %d %% ou %d des participations, selon le plus élevé",
+		"🚫 Dropped the ballots of %d voters who played too few submissions: %s":                            "🚫 Bulletins écartés de %d votants ayant joué trop peu de participations : %s",
+		"...and %d more patterns": "...et %d autres schémas",
+	})
+
+	i18n.Register(language.German, map[string]string{
+		"Count votes and determine contest winners": "Stimmen zählen und Gewinner des Wettbewerbs ermitteln",
+		"Forum or text channel of the contest":      "Forum oder Textkanal des Wettbewerbs",
+
+		"Manage contest configurations":                               "Wettbewerbskonfigurationen verwalten",
+		"Configure a new contest":                                     "Neuen Wettbewerb konfigurieren",
+		"Show the configuration of a contest":                         "Konfiguration eines Wettbewerbs anzeigen",
+		"Edit the configuration of a contest":                         "Konfiguration eines Wettbewerbs bearbeiten",
+		"Delete the configuration of a contest":                       "Konfiguration eines Wettbewerbs löschen",
+		"Always ignore the votes of this member":                      "Stimmen dieses Mitglieds immer ignorieren",
+		"Always ignore the submissions of this member":                "Einreichungen dieses Mitglieds immer ignorieren",
+		"Lift standing exclusions of this member":                     "Dauerhafte Ausschlüsse dieses Mitglieds aufheben",
+		"Name of the contest":                                         "Name des Wettbewerbs",
+		"Emoji marking a submission as played":                        "Emoji, das eine Einreichung als gespielt markiert",
+		"Emoji of the 'best overall' category":                        "Emoji der Kategorie „insgesamt am besten“",
+		"Emojis of the other categories, comma-separated":             "Emojis der anderen Kategorien, durch Kommas getrennt",
+		"Max votes a voter may give a single submission":              "Höchstzahl an Stimmen pro Person für eine Einreichung",
+		"End of voting (UTC), e.g. 2024-05-31 18:00; 'none' to clear": "Ende der Abstimmung (UTC), z. B. 2024-05-31 18:00; „none“ zum Entfernen",
+
+		"<#%s> already has a contest configuration: use `/%s %s` instead.": "<#%s> hat bereits eine Wettbewerbskonfiguration: verwende stattdessen `/%s %s`.",
+		"<#%s> has no contest configuration: use `/%s %s` first.":          "<#%s> hat keine Wettbewerbskonfiguration: verwende zuerst `/%s %s`.",
+		"<#%s> has no contest configuration, so the defaults apply:":       "<#%s> hat keine Wettbewerbskonfiguration, es gelten die Standardwerte:",
+		"Invalid settings: %v.":                     "Ungültige Einstellungen: %v.",
+		"Failed to save configuration: %v.":         "Konfiguration konnte nicht gespeichert werden: %v.",
+		"Failed to load contest configuration: %v.": "Wettbewerbskonfiguration konnte nicht geladen werden: %v.",
+		"Saved!": "Gespeichert!",
+		"Check a results snapshot against its published hash, and recount from it": "Ergebnis-Schnappschuss mit seinem veröffentlichten Hash abgleichen und daraus neu zählen",
+		"Snapshot hash published with the results":                                 "Mit den Ergebnissen veröffentlichter Hash des Schnappschusses",
+		"Invalid hash.":                      "Ungültiger Hash.",
+		"No snapshot stored with this hash.": "Kein Schnappschuss mit diesem Hash gespeichert.",
+		"⚠️ The stored snapshot does NOT match its hash: it was modified after the results were published!": "⚠️ Der gespeicherte Schnappschuss passt NICHT zu seinem Hash: er wurde nach der Veröffentlichung der Ergebnisse verändert!",
+		"✅ The stored snapshot matches its hash: %d submissions in <#%s>. Recounting from it...":            "✅ Der gespeicherte Schnappschuss passt zu seinem Hash: %d Einreichungen in <#%s>. Es wird daraus neu gezählt...",
+		"⚠️ Failed to record a snapshot of the data behind these results: %v.":                              "⚠️ Schnappschuss der Daten hinter diesen Ergebnissen konnte nicht gespeichert werden: %v.",
+		"🔏 Snapshot of the data behind these results: `%s`":                                                 "🔏 Schnappschuss der Daten hinter diesen Ergebnissen: `%s`",
+		"OK!":                          "OK!",
+		"_unnamed_":                    "_unbenannt_",
+		"**%s** in <#%s>":              "**%s** in <#%s>",
+		"Played: %s":                   "Gespielt: %s",
+		"Best overall: %s":             "Insgesamt am besten: %s",
+		"Other categories: %s":         "Weitere Kategorien: %s",
+		"Max votes per submission: %d": "Höchstzahl an Stimmen pro Einreichung: %d",
+		"Deadline: %s":                 "Frist: %s",
+		"Excluded voters: %s":          "Ausgeschlossene Abstimmende: %s",
+		"Excluded contestants: %s":     "Ausgeschlossene Teilnehmende: %s",
+		"⚠️ This server has no emoji named %s!":                                    "⚠️ Dieser Server hat kein Emoji namens %s!",
+		"Voting in <#%s> is open until %s: results can't be revealed before then!": "Die Abstimmung in <#%s> läuft bis %s: vorher können keine Ergebnisse verraten werden!",
+
+		"Fetching list of participants, and then waiting for caller input. Be patient!": "Teilnehmerliste wird abgerufen, danach wird auf Eingaben der Organisation gewartet. Geduld!",
+		"Failed to fetch data: %v.":                "Daten konnten nicht abgerufen werden: %v.",
+		"Fetching data and determining results...": "Daten werden abgerufen und Ergebnisse ermittelt...",
+		progressSubmissions:                        "(%d/%d Einreichungen abgerufen)",
+		progressParticipants:                       "(%d/%d Teilnehmende geprüft)",
+		"Excluded Voters":                          "Ausgeschlossene Abstimmende",
+		"Excluded Contestants":                     "Ausgeschlossene Teilnehmende",
+		"Validate Only":                            "Nur prüfen",
+		"Get Results":                              "Ergebnisse",
+		"Cancel":                                   "Abbrechen",
+
+		"Ignored voters: ":                                  "Ignorierte Abstimmende: ",
+		"Ignored contestants: ":                             "Ignorierte Teilnehmende: ",
+		"Oops! Failed to get the data from <#%v>: %v.":      "Hoppla! Die Daten aus <#%v> konnten nicht abgerufen werden: %v.",
+		"Oops! Failed to get the emojis of the server: %v.": "Hoppla! Die Emojis des Servers konnten nicht abgerufen werden: %v.",
+		"Oh no! Found some irregularities:":                 "Oh nein! Es wurden Unregelmäßigkeiten gefunden:",
+		"...so the results shouldn't be trusted. 😿":         "...den Ergebnissen ist also nicht zu trauen. 😿",
+		"Ooof! Could not determine _any_ winners in <#%v>!": "Autsch! In <#%v> konnte _kein einziger_ Gewinner ermittelt werden!",
+		"Could not determine winners for all categories: undecidable ties, or too few eligible submissions. Sad. Well, anyway...": "Nicht für alle Kategorien konnten Gewinner ermittelt werden: unentscheidbare Gleichstände oder zu wenige gültige Einreichungen. Schade. Nun ja...",
+		"Validating <#%s> without revealing results...": "Prüfe <#%s>, ohne die Ergebnisse zu verraten...",
+		"No irregularities in <#%s>! 👏":                 "Keine Unregelmäßigkeiten in <#%s>! 👏",
+		"🥁 Without further ado, the winners of <#%s>:":  "🥁 Ohne weitere Umschweife, die Gewinner von <#%s>:",
+		"Congratulations! 🎉":                            "Herzlichen Glückwunsch! 🎉",
+
+		"COULD NOT BREAK TIE! OVERALL WINNER HAS FEWER POINTS THAN OTHER SUBMISSIONS!": "GLEICHSTAND NICHT AUFLÖSBAR! DER GESAMTSIEGER HAT WENIGER PUNKTE ALS ANDERE EINREICHUNGEN!",
+		"... but shouldn't %s have won?!?":                                             "... aber hätte nicht %s gewinnen müssen?!?",
+		"more votes, but won something else:":                                          "mehr Stimmen, aber etwas anderes gewonnen:",
+		"tied number of votes:":                                                        "gleiche Anzahl an Stimmen:",
+		"(tie broken in favour of the earliest submission)":                            "(Gleichstand zugunsten der frühesten Einreichung aufgelöst)",
+
+		"Empty post":       "Leerer Beitrag",
+		"Best overall! ":   "Insgesamt am besten! ",
+		"Most %s: ":        "Am meisten %s: ",
+		"_unknown_":        "_unbekannt_",
+		" — with %v %s":    " — mit %v %s",
+		" — with %v vote":  " — mit %v Stimme",
+		" — with %v votes": " — mit %v Stimmen",
+
+		"made more than one submission":               "mehr als eine Einreichung gemacht",
+		"voted for their own submission":              "für die eigene Einreichung gestimmt",
+		"gave out %d %s":                              "%d %s vergeben",
+		"gave out %d instead of max %d votes overall": "insgesamt %d statt höchstens %d Stimmen vergeben",
+		"gave out too many votes to %s":               "zu viele Stimmen an %s vergeben",
+		"voted without reacting with %s on %s":        "ohne Reaktion mit %s abgestimmt bei %s",
+		"seem to not have made any efforts in voting despite making a contest submission": "trotz eigener Einreichung anscheinend keinerlei Mühe beim Abstimmen gegeben",
+		"_and_ %s": "_und_ %s",
+		"%s is on the naughty list! They %s! 🙀": "%s steht auf der Liste der Unartigen! Es wurde %s! 🙀",
+
+		"🔍 Suspicious patterns, for organizers to review:":                               "🔍 Verdächtige Muster, zur Prüfung durch die Organisation:",
+		"%s and %s voted for each other's submissions.":                                  "%s und %s haben gegenseitig für ihre Einreichungen gestimmt.",
+		"%s only voted for submissions of contestants who voted for them in return: %s.": "%s hat nur für Teilnehmende gestimmt, die im Gegenzug auch für sie gestimmt haben: %s.",
+		"%s cast nearly identical ballots.":                                              "%s haben nahezu identisch abgestimmt.",
+		"%s: new submission":                                                             "%s: neue Einreichung",
+		"%s: submission gone":                                                            "%s: Einreichung verschwunden",
+		"...and %d more submissions with changes":                                        "...und %d weitere geänderte Einreichungen",
+		"✅ no longer: %s":                                                                "✅ nicht mehr: %s",
+		"🏆 Some category winners changed, but that stays secret until the results are revealed.": "🏆 Einige Kategoriegewinner haben sich geändert, aber das bleibt geheim, bis die Ergebnisse verraten werden.",
+		"_nobody_": "_niemand_",
+		"🔁 Changes since the previous count (%s):": "🔁 Änderungen seit der letzten Zählung (%s):",
+		"none.":              "keine.",
+		"Configured contest": "Konfigurierter Wettbewerb",
+		"Forum or text channel of the contest, if not configured":                        "Forum oder Textkanal des Wettbewerbs, falls nicht konfiguriert",
+		"Pick a contest or a channel.":                                                   "Wähle einen Wettbewerb oder einen Kanal.",
+		"unnamed contest in channel %s":                                                  "unbenannter Wettbewerb im Kanal %s",
+		"Places awarded in the 'best overall' category":                                  "Vergebene Plätze in der Kategorie „insgesamt am besten“",
+		"Places awarded in each of the other categories":                                 "Vergebene Plätze in jeder der anderen Kategorien",
+		"Number of honorable mentions for submissions that just missed":                  "Anzahl lobender Erwähnungen für knapp gescheiterte Beiträge",
+		"Places: %d overall, %d in each other category":                                  "Plätze: %d insgesamt, %d in jeder anderen Kategorie",
+		"Honorable mentions: %d":                                                         "Lobende Erwähnungen: %d",
+		"#%d overall: ":                                                                  "Platz %d insgesamt: ",
+		"#%d in %s: ":                                                                    "Platz %d in %s: ",
+		"🎖️ Honorable mentions:":                                                         "🎖️ Lobende Erwähnungen:",
+		"Let a submission win several categories":                                        "Einem Beitrag erlauben, mehrere Kategorien zu gewinnen",
+		"A submission may win several categories":                                        "Ein Beitrag kann mehrere Kategorien gewinnen",
+		"Reveal Step by Step":                                                            "Schrittweise verraten",
+		"Reveal the results whenever ready:":                                             "Verrate die Ergebnisse, wann immer du bereit bist:",
+		"Reveal Next (%d/%d)":                                                            "Nächstes verraten (%d/%d)",
+		"Nothing left to reveal.":                                                        "Nichts mehr zu verraten.",
+		"Failed to save the results to reveal: %v.":                                      "Die zu verratenden Ergebnisse konnten nicht gespeichert werden: %v.",
+		"🥁 The winners of <#%s> will now be revealed, one category at a time!":           "🥁 Die Gewinner von <#%s> werden jetzt verraten, eine Kategorie nach der anderen!",
+		"🥁 And in %s...":                                                                 "🥁 Und in %s...",
+		"🥁 And finally, best overall...":                                                 "🥁 Und schließlich, insgesamt am besten...",
+		"Submissions voters must have played to be rewarded":                             "Beiträge, die Wähler gespielt haben müssen, um belohnt zu werden",
+		"Role granted to voters who took part properly":                                  "Rolle für Wähler, die regelkonform teilgenommen haben",
+		"Voter role: <@&%s>, for playing at least %d submissions":                        "Wählerrolle: <@&%s>, für mindestens %d gespielte Beiträge",
+		"Reward Voters":                                                                  "Wähler belohnen",
+		"No voter role configured for <#%s>: set one with `/%s %s`.":                     "Keine Wählerrolle für <#%s> konfiguriert: lege eine mit `/%s %s` fest.",
+		"No voter in <#%s> played at least %d submissions without breaking any rule.":    "Kein Wähler in <#%s> hat mindestens %d Beiträge gespielt, ohne eine Regel zu brechen.",
+		"🗳️ Thanks for voting! <@&%s> granted to %s.":                                    "🗳️ Danke fürs Abstimmen! <@&%s> vergeben an %s.",
+		"⚠️ Failed to grant the role to %d of %d voters:":                                "⚠️ Die Rolle konnte %d von %d Wählern nicht vergeben werden:",
+		"🗳️ %d voters played at least %d submissions without breaking any rule.":         "🗳️ %d Wähler haben mindestens %d Beiträge gespielt, ohne eine Regel zu brechen.",
+		"Percentage of the submissions voters must have played for their votes to count": "Prozentsatz der Beiträge, die Wähler gespielt haben müssen, damit ihre Stimmen zählen",
+		"Number of submissions voters must have played for their votes to count":         "Anzahl der Beiträge, die Wähler gespielt haben müssen, damit ihre Stimmen zählen",
+		"Votes only count for voters who played at least %d%% or %d of the submissions, whichever is more": "Stimmen zählen nur für Wähler, die mindestens %d %% oder %d der Beiträge gespielt haben, je nachdem, was mehr ist",
+		"🚫 Dropped the ballots of %d voters who played too few submissions: %s":                            "🚫 Stimmzettel von %d Wählern verworfen, die zu wenige Beiträge gespielt haben: %s",
+		"...and %d more patterns": "...und %d weitere Muster",
+	})
+}
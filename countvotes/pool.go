@@ -0,0 +1,93 @@
+package countvotes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+)
+
+const (
+	// discordgo already waits out rate limit buckets and retries on 429s, but hammering it
+	// with hundreds of concurrent requests just piles them up behind the same bucket.
+	maxConcurrentRequests = 4
+
+	progressUpdateInterval = 2 * time.Second
+)
+
+// forEachBounded calls f for every index in [0, n) on at most maxConcurrentRequests goroutines.
+// It does not stop at the first failure: all errors are returned, in no particular order.
+// If non-nil, progress is called after every completed call.
+func forEachBounded(n int, f func(i int) error, progress func(done, total int)) []error {
+	var (
+		mu   sync.Mutex
+		errs []error
+		done int
+		wg   sync.WaitGroup
+	)
+
+	indices := make(chan int)
+	for range min(n, maxConcurrentRequests) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				err := f(i)
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, err)
+				}
+				done++
+				d := done
+				mu.Unlock()
+
+				if progress != nil {
+					progress(d, n)
+				}
+			}
+		}()
+	}
+	for i := range n {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return errs
+}
+
+// progressReporter returns a progress callback that edits the interaction response,
+// skipping updates when the previous one was too recent or is still in flight.
+// The final update always waits for its turn, so that the response does not stay short of done.
+func progressReporter(s *discordgo.Session, i *discordgo.Interaction, p *message.Printer, prefix, format string) func(done, total int) {
+	return throttledProgress(func(done, total int) {
+		content := prefix + " " + p.Sprintf(format, done, total)
+		s.InteractionResponseEdit(i, &discordgo.WebhookEdit{Content: &content}) // not worth failing over
+	})
+}
+
+func throttledProgress(report func(done, total int)) func(done, total int) {
+	var (
+		mu       sync.Mutex
+		last     time.Time
+		lastDone int
+	)
+	return func(done, total int) {
+		if done < total {
+			if !mu.TryLock() {
+				return
+			}
+		} else {
+			mu.Lock()
+		}
+		defer mu.Unlock()
+		// updates may come in out of order
+		if done <= lastDone || (done < total && time.Since(last) < progressUpdateInterval) {
+			return
+		}
+		last, lastDone = time.Now(), done
+		report(done, total)
+	}
+}
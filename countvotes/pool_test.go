@@ -0,0 +1,64 @@
+package countvotes
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestForEachBounded(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		visited = make(map[int]bool)
+		last    int
+	)
+	errs := forEachBounded(20, func(i int) error {
+		mu.Lock()
+		visited[i] = true
+		mu.Unlock()
+		if i%5 == 0 {
+			return errors.New("failed")
+		}
+		return nil
+	}, func(done, total int) {
+		mu.Lock()
+		last = max(last, done)
+		mu.Unlock()
+	})
+	if len(visited) != 20 || len(errs) != 4 || last != 20 {
+		t.Errorf("visited %d, %d errors, progress up to %d", len(visited), len(errs), last)
+	}
+}
+
+func TestThrottledProgressFinal(t *testing.T) {
+	var (
+		reported []int
+		inFlight = make(chan struct{})
+		release  = make(chan struct{})
+	)
+	progress := throttledProgress(func(done, total int) {
+		reported = append(reported, done)
+		if done == 1 {
+			close(inFlight)
+			<-release
+		}
+	})
+
+	go progress(1, 3)
+	<-inFlight
+	progress(2, 3) // dropped, the first update is still in flight
+	finished := make(chan struct{})
+	go func() {
+		progress(3, 3)
+		close(finished)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	<-finished
+	progress(2, 3) // late, after the final update
+
+	if len(reported) != 2 || reported[0] != 1 || reported[1] != 3 {
+		t.Errorf("reported %v, want [1 3]", reported)
+	}
+}
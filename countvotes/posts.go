@@ -5,73 +5,129 @@ import (
 	"fmt"
 	"log"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
 )
 
 type post struct {
-	thread    string
+	id        string // snowflake of the submission, i.e. its creation time
+	title     string // how to refer to the submission in messages
 	author    string
 	reactions map[string][]string
-	won       string
+	awards    []award // only ever one, unless submissions may win several categories
+}
+
+type award struct {
+	category  string
+	place     int // starting at 1
+	tieBroken bool
+}
+
+func (p post) hasWon(category string) bool {
+	_, ok := p.award(category)
+	return ok
 }
 
 // If tied in number of votes in that category, try to break the tie by considering
 // first ratio to number of plays, then consider total votes across all categories,
 // and finally votes in the overall category.
-func postCmp(e string) func(p, q *post) int {
+// Remaining ties are left for `winners` to settle by submission time.
+func (con contest) postCmp(e string) func(p, q *post) int {
+	played, main := con.cfg.PlayedEmoji, con.cfg.MainEmoji
 	return func(p, q *post) int {
 		if pc, qc := p.numReact(e), q.numReact(e); pc != qc {
 			return qc - pc
 		}
-		pn, qn := p.numReact(emojiPlayed), q.numReact(emojiPlayed)
+		pn, qn := p.numReact(played), q.numReact(played)
 		if pn != qn {
 			return pn - qn
 		}
-		if pc, qc := qn*p.totVotes(), pn*q.totVotes(); pc != qc {
+		if pc, qc := qn*p.totVotes(played), pn*q.totVotes(played); pc != qc {
 			return qc - pc
 		}
-		if pc, qc := qn*p.numReact(emojiMain), pn*q.numReact(emojiMain); pc != qc {
+		if pc, qc := qn*p.numReact(main), pn*q.numReact(main); pc != qc {
 			return qc - pc
 		}
 		return 0
 	}
 }
 
-func (p post) String() string {
-	if p.thread == "" {
-		return "Empty post"
+// format describes the submission along with its awards: as a title in front if only one,
+// or listed after it if several.
+func (p post) format(pr *message.Printer, es emojiSet, mainEmoji string) string {
+	if p.title == "" {
+		return pr.Sprintf("Empty post")
 	}
 
-	var str string
+	str := p.who(pr)
 
-	if p.won == emojiMain {
-		str += "Best overall! "
-	} else if p.won != "" {
-		str += fmt.Sprintf("Most %s: ", p.won)
+	switch len(p.awards) {
+	case 0:
+		return str
+	case 1:
+		return p.formatAward(pr, es, mainEmoji, p.awards[0])
+	}
+
+	var titles []string
+	for _, a := range p.awards {
+		titles = append(titles, strings.TrimRight(a.label(pr, mainEmoji), " :")+p.votes(pr, es, a))
 	}
+	return str + ": " + strings.Join(titles, "; ")
+}
+
+// formatAward describes the submission with only one of its awards.
+func (p post) formatAward(pr *message.Printer, es emojiSet, mainEmoji string, a award) string {
+	return a.label(pr, mainEmoji) + p.who(pr) + p.votes(pr, es, a)
+}
 
-	author := "_unknown_"
+// who is the title of the submission, along with its author
+func (p post) who(pr *message.Printer) string {
+	author := pr.Sprintf("_unknown_")
 	if p.author != "" {
 		author = userMention(p.author)
 	}
-	str += fmt.Sprintf("%s (%s)", p.thread, author)
+	return fmt.Sprintf("%s (%s)", p.title, author)
+}
 
-	if p.won == "" {
-		return str
+func (p post) award(category string) (award, bool) {
+	for _, a := range p.awards {
+		if a.category == category {
+			return a, true
+		}
+	}
+	return award{}, false
+}
+
+func (a award) label(pr *message.Printer, mainEmoji string) string {
+	switch {
+	case a.place > 1 && a.category == mainEmoji:
+		return pr.Sprintf("#%d overall: ", a.place)
+	case a.place > 1:
+		return pr.Sprintf("#%d in %s: ", a.place, a.category)
+	case a.category == mainEmoji:
+		return pr.Sprintf("Best overall! ")
+	default:
+		return pr.Sprintf("Most %s: ", a.category)
 	}
+}
 
-	name := "votes"
-	n := p.numReact(p.won)
+func (p post) votes(pr *message.Printer, es emojiSet, a award) string {
+	var str string
+	n := p.numReact(a.category)
 	// Probably always true, but just to be safe...
-	if e := knownEmojis[p.won]; e != nil {
-		name = e.MessageFormat()
+	if e := es[a.category]; e != nil {
+		str = pr.Sprintf(" — with %v %s", n, e.MessageFormat())
 	} else if n == 1 {
-		name = "vote"
+		str = pr.Sprintf(" — with %v vote", n)
+	} else {
+		str = pr.Sprintf(" — with %v votes", n)
+	}
+	if a.tieBroken {
+		str += " " + pr.Sprintf("(tie broken in favour of the earliest submission)")
 	}
-	str += fmt.Sprintf(" — with %v %s", n, name)
-
 	return str
 }
 
@@ -79,10 +135,10 @@ func (p post) numReact(e string) int {
 	return len(p.reactions[e])
 }
 
-func (p post) totVotes() int {
+func (p post) totVotes(playedEmoji string) int {
 	t := 0
 	for e, u := range p.reactions {
-		if e == emojiPlayed {
+		if e == playedEmoji {
 			continue
 		}
 		t += len(u)
@@ -90,7 +146,18 @@ func (p post) totVotes() int {
 	return t
 }
 
-func fetchPosts(s *discordgo.Session, guildID, chanID string, excludedVoters, excludedContestants map[string]bool) ([]*post, error) {
+// submission is where a contest entry lives: the starter message of a forum thread,
+// or a plain message in a text channel.
+type submission struct {
+	channelID string
+	messageID string
+	title     string
+	// already known for text channel messages, fetched on the go for forum threads
+	msg *discordgo.Message
+}
+
+// fetchPosts returns the posts it managed to fetch, along with an error listing all failures, if any.
+func fetchPosts(s *discordgo.Session, cfg contestConfig, excludedVoters, excludedContestants map[string]bool, progress func(done, total int)) ([]*post, error) {
 	var posts []*post
 
 	t0 := time.Now()
@@ -98,82 +165,161 @@ func fetchPosts(s *discordgo.Session, guildID, chanID string, excludedVoters, ex
 		log.Printf("fetching %v posts took %v", len(posts), time.Since(t0))
 	}()
 
-	active, err := s.GuildThreadsActive(guildID)
+	c, err := s.Channel(cfg.Channel)
 	if err != nil {
 		return nil, err
 	}
-	threads := slices.DeleteFunc(active.Threads, func(c *discordgo.Channel) bool {
-		return c.ParentID != chanID
-	})
-	archived, err := s.ThreadsArchived(chanID, nil, 0)
+	var subs []submission
+	switch c.Type {
+	case discordgo.ChannelTypeGuildForum:
+		subs, err = forumSubmissions(s, cfg, excludedContestants)
+	case discordgo.ChannelTypeGuildText:
+		subs, err = textChannelSubmissions(s, cfg, excludedContestants)
+	default:
+		err = fmt.Errorf("unsupported channel type %v", c.Type)
+	}
 	if err != nil {
 		return nil, err
 	}
-	threads = append(threads, archived.Threads...)
 
-	threads = slices.DeleteFunc(threads, func(c *discordgo.Channel) bool {
-		return excludedContestants[c.OwnerID]
-	})
+	fetched := make([]*post, len(subs))
+	errs := forEachBounded(len(subs), func(i int) error {
+		sub := subs[i]
+		msg := sub.msg
+		if msg == nil {
+			var err error
+			msg, err = s.ChannelMessage(sub.channelID, sub.messageID)
+			if err != nil {
+				return fmt.Errorf("%s: %w", sub.title, err)
+			}
+		}
 
-	postsChan := make(chan *post, len(threads))
-	errChan := make(chan error, len(threads))
-	for _, thread := range threads {
-		go func(thread *discordgo.Channel) {
-			msg, err := s.ChannelMessage(thread.ID, thread.ID)
+		rcts := make(map[string][]string)
+		for _, react := range msg.Reactions {
+			if !cfg.isContestEmoji(react.Emoji.Name) {
+				continue
+			}
+
+			// If the number of voters gets over 100... would need to scroll through pages.
+			// We're far needing this at the moment, though.
+			users, err := s.MessageReactions(sub.channelID, sub.messageID, react.Emoji.APIName(), 100, "", "")
 			if err != nil {
-				errChan <- err
-				return
+				return fmt.Errorf("%s: %w", sub.title, err)
 			}
 
-			rcts := make(map[string][]string)
-			for _, react := range msg.Reactions {
-				if _, ok := knownEmojis[react.Emoji.Name]; !ok {
+			var userStrings []string
+			for _, u := range users {
+				if excludedVoters[u.ID] {
 					continue
 				}
-				knownEmojis[react.Emoji.Name] = react.Emoji
-
-				// If the number of voters gets over 100... would need to scroll through pages.
-				// We're far needing this at the moment, though.
-				users, err := s.MessageReactions(thread.ID, thread.ID, react.Emoji.APIName(), 100, "", "")
-				if err != nil {
-					errChan <- err
-					return
-				}
-
-				var userStrings []string
-				for _, u := range users {
-					if excludedVoters[u.ID] {
-						continue
-					}
-					userStrings = append(userStrings, u.ID)
-				}
-				rcts[react.Emoji.Name] = userStrings
+				userStrings = append(userStrings, u.ID)
 			}
+			rcts[react.Emoji.Name] = userStrings
+		}
 
-			postsChan <- &post{
-				thread:    thread.Mention(),
-				author:    msg.Author.ID,
-				reactions: rcts,
-			}
-		}(thread)
-	}
+		fetched[i] = &post{
+			id:        sub.messageID,
+			title:     sub.title,
+			author:    msg.Author.ID,
+			reactions: rcts,
+		}
+		return nil
+	}, progress)
 
-	for range threads {
-		select {
-		case p := <-postsChan:
+	for _, p := range fetched {
+		if p != nil {
 			posts = append(posts, p)
-		case err := <-errChan:
-			return nil, err
 		}
 	}
+	// goroutines finish in any order; evaluation should not depend on that
+	slices.SortFunc(posts, func(p, q *post) int {
+		return snowflakeCmp(p.id, q.id)
+	})
 
+	if len(errs) > 0 {
+		return posts, fmt.Errorf("failed to fetch %d of %d submissions:\n%w", len(errs), len(subs), errors.Join(errs...))
+	}
 	if len(posts) == 0 {
-		return nil, errors.New("did not find any posts in the thread")
+		return nil, errors.New("did not find any submissions in the channel")
 	}
 
 	return posts, nil
 }
 
+func forumSubmissions(s *discordgo.Session, cfg contestConfig, excludedContestants map[string]bool) ([]submission, error) {
+	active, err := s.GuildThreadsActive(cfg.GuildID)
+	if err != nil {
+		return nil, err
+	}
+	threads := slices.DeleteFunc(active.Threads, func(c *discordgo.Channel) bool {
+		return c.ParentID != cfg.Channel
+	})
+	archived, err := s.ThreadsArchived(cfg.Channel, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	threads = append(threads, archived.Threads...)
+
+	var subs []submission
+	for _, thread := range threads {
+		if excludedContestants[thread.OwnerID] {
+			continue
+		}
+		subs = append(subs, submission{channelID: thread.ID, messageID: thread.ID, title: thread.Mention()})
+	}
+	return subs, nil
+}
+
+// Every message of a participant in the channel is a submission: bots and system messages aside.
+func textChannelSubmissions(s *discordgo.Session, cfg contestConfig, excludedContestants map[string]bool) ([]submission, error) {
+	const pageSize = 100
+
+	var subs []submission
+	before := ""
+	for {
+		msgs, err := s.ChannelMessages(cfg.Channel, pageSize, before, "", "")
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range msgs {
+			if msg.Author == nil || msg.Author.Bot || excludedContestants[msg.Author.ID] {
+				continue
+			}
+			if msg.Type != discordgo.MessageTypeDefault && msg.Type != discordgo.MessageTypeReply {
+				continue
+			}
+			subs = append(subs, submission{
+				channelID: cfg.Channel,
+				messageID: msg.ID,
+				title:     messageLink(cfg.GuildID, cfg.Channel, msg.ID),
+				msg:       msg,
+			})
+		}
+		if len(msgs) < pageSize {
+			break
+		}
+		before = msgs[len(msgs)-1].ID
+	}
+	return subs, nil
+}
+
+func messageLink(guildID, channelID, messageID string) string {
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", guildID, channelID, messageID)
+}
+
+// snowflakes are decimal numbers without leading zeros, growing with time
+func snowflakeCmp(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// timestamp is formatted outside of message printers, which would otherwise group the digits
+func timestamp(t time.Time) string {
+	return fmt.Sprintf("<t:%d:f>", t.Unix())
+}
+
 func userMention(id string) string {
 	return (&discordgo.User{ID: id}).Mention()
 }
@@ -0,0 +1,173 @@
+package countvotes
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/store"
+	"golang.org/x/text/message"
+)
+
+const (
+	revealCollection = "reveals"
+
+	buttonReveal     = "reveal"
+	buttonRevealNext = "reveal_next"
+)
+
+// a double click should not reveal two steps at once, nor the same one twice
+var revealMutex sync.Mutex
+
+// reveal holds results computed once, to be posted one step at a time:
+// whatever happens to the votes in the meantime, the reveal goes on with the same results.
+type reveal struct {
+	Steps []string `json:"steps"`
+	Next  int      `json:"next"`
+}
+
+func revealCollectionOf(guildID string) string {
+	return store.Collection(revealCollection, guildID)
+}
+
+func loadReveal(guildID, channel string) (reveal, bool, error) {
+	var r reveal
+	ok, err := store.Get(revealCollectionOf(guildID), channel, &r)
+	return r, ok, err
+}
+
+func saveReveal(guildID, channel string, r reveal) error {
+	return store.Put(revealCollectionOf(guildID), channel, r)
+}
+
+func deleteReveal(guildID, channel string) error {
+	return store.Delete(revealCollectionOf(guildID), channel)
+}
+
+// prepareReveal determines the results like `determineResults`, but stores them as steps to reveal
+// instead of returning them. It returns the introduction to post, and the reveal if there is anything to reveal.
+func prepareReveal(s *discordgo.Session, p *message.Printer, cfg contestConfig, opts options, progress func(done, total int)) (string, reveal, bool) {
+	con, excludedVoters, resp, ok := prepareContest(s, p, cfg, opts, progress)
+	if !ok {
+		return resp, reveal{}, false
+	}
+	snap := newSnapshot(con, opts)
+	report, _ := con.report(p, excludedVoters)
+	resp += report
+
+	steps := con.revealSteps(p)
+	if len(steps) == 0 {
+		return resp + p.Sprintf("Ooof! Could not determine _any_ winners in <#%v>!", cfg.Channel), reveal{}, false
+	}
+	if err := saveLastRun(snap); err != nil {
+		log.Printf("failed to save run of %v: %v", cfg.Channel, err)
+	}
+	steps[len(steps)-1] += "\n\n" + recordSnapshot(p, snap)
+
+	r := reveal{Steps: steps}
+	if err := saveReveal(cfg.GuildID, cfg.Channel, r); err != nil {
+		return resp + p.Sprintf("Failed to save the results to reveal: %v.", err), reveal{}, false
+	}
+	return resp + p.Sprintf("🥁 The winners of <#%s> will now be revealed, one category at a time!", cfg.Channel), r, true
+}
+
+// revealSteps describes the winners one category at a time, from the lowest place up, and saving
+// the main category for last. Honorable mentions, if any, open the show. Ballots that do not count are dropped first.
+func (con contest) revealSteps(p *message.Printer) []string {
+	con, _ = con.counted()
+	if len(con.posts) == 0 {
+		return nil
+	}
+	res := con.pickWinners()
+
+	var steps []string
+	if len(res.mentions) > 0 {
+		var mentions []string
+		for _, m := range res.mentions {
+			mentions = append(mentions, m.who(p))
+		}
+		steps = append(steps, p.Sprintf("🎖️ Honorable mentions:")+"\n- "+strings.Join(mentions, "\n- "))
+	}
+
+	for _, cat := range append(slices.Clone(con.cfg.SecondaryEmojis), con.cfg.MainEmoji) {
+		podium := res.podium[cat]
+		if len(podium) == 0 {
+			continue
+		}
+
+		var lines []string
+		for k := len(podium) - 1; k >= 0; k-- {
+			w := podium[k]
+			a, _ := w.award(cat)
+			lines = append(lines, w.formatAward(p, con.emojis, con.cfg.MainEmoji, a))
+		}
+		header := p.Sprintf("🥁 And in %s...", con.emojis.format(cat))
+		if cat == con.cfg.MainEmoji {
+			header = p.Sprintf("🥁 And finally, best overall...")
+			if podium[0].numReact(cat) < res.mainCategoryMaxVotes {
+				header += "\n" + p.Sprintf("COULD NOT BREAK TIE! OVERALL WINNER HAS FEWER POINTS THAN OTHER SUBMISSIONS!")
+			}
+		}
+		steps = append(steps, header+"\n- "+strings.Join(lines, "\n- "))
+	}
+	if len(steps) > 0 {
+		steps[len(steps)-1] += "\n\n" + p.Sprintf("Congratulations! 🎉")
+	}
+	return steps
+}
+
+// revealControls let the organizer post the next step whenever ready.
+func revealControls(p *message.Printer, channel string, r reveal) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    p.Sprintf("Reveal Next (%d/%d)", r.Next+1, len(r.Steps)),
+					Style:    discordgo.PrimaryButton,
+					CustomID: ApplicationCommand.Name + ":" + channel + ":" + buttonRevealNext,
+				},
+			},
+		},
+	}
+}
+
+// revealNext posts the next step of the reveal in the channel the reveal started in,
+// and updates the controls, removing them once everything is revealed.
+func revealNext(s *discordgo.Session, i *discordgo.InteractionCreate, p *message.Printer, channel string) error {
+	revealMutex.Lock()
+	defer revealMutex.Unlock()
+
+	r, ok, err := loadReveal(i.GuildID, channel)
+	if err != nil {
+		return err
+	}
+	if !ok || r.Next >= len(r.Steps) {
+		content := p.Sprintf("Nothing left to reveal.")
+		_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content:    &content,
+			Components: &([]discordgo.MessageComponent{}),
+		})
+		return err
+	}
+
+	if _, err := s.ChannelMessageSend(i.Message.MessageReference.ChannelID, r.Steps[r.Next]); err != nil {
+		return fmt.Errorf("failed to post reveal step: %w", err)
+	}
+	r.Next++
+
+	if r.Next == len(r.Steps) {
+		if err := deleteReveal(i.GuildID, channel); err != nil {
+			return err
+		}
+		return s.InteractionResponseDelete(i.Interaction)
+	}
+	if err := saveReveal(i.GuildID, channel, r); err != nil {
+		return err
+	}
+	controls := revealControls(p, channel, r)
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Components: &controls})
+	return err
+}
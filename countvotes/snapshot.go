@@ -0,0 +1,119 @@
+package countvotes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"github.com/itizir/hrv/store"
+)
+
+const snapshotCollection = "snapshots"
+
+// snapshot is the canonical record of everything results were computed from:
+// the contest configuration with the effective exclusions, and every post with its reactions.
+// Its hash is published along with the results, so that any recount can be checked against it.
+type snapshot struct {
+	Config contestConfig  `json:"config"`
+	Posts  []snapshotPost `json:"posts"`
+}
+
+type snapshotPost struct {
+	ID        string              `json:"id"`
+	Title     string              `json:"title"`
+	Author    string              `json:"author"`
+	Reactions map[string][]string `json:"reactions"`
+}
+
+func newSnapshot(con contest, opts options) snapshot {
+	cfg := con.cfg
+	cfg.ExcludedVoters = sorted(opts.excludedVoters)
+	cfg.ExcludedContestants = sorted(opts.excludedContestants)
+
+	snap := snapshot{Config: cfg}
+	for _, p := range con.posts {
+		sp := snapshotPost{ID: p.id, Title: p.title, Author: p.author, Reactions: make(map[string][]string)}
+		for k, v := range p.reactions {
+			if len(v) > 0 {
+				sp.Reactions[k] = sorted(v)
+			}
+		}
+		snap.Posts = append(snap.Posts, sp)
+	}
+	slices.SortFunc(snap.Posts, func(p, q snapshotPost) int {
+		return snowflakeCmp(p.ID, q.ID)
+	})
+	return snap
+}
+
+// encode gives the bytes the hash is computed over. JSON sorts map keys: together with the sorting
+// done when taking the snapshot, the same data always gives the same hash.
+func (snap snapshot) encode() (data []byte, hash string, err error) {
+	data, err = json.Marshal(snap)
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(data)
+	return data, hex.EncodeToString(sum[:]), nil
+}
+
+// contest rebuilds the contest as it was evaluated when the snapshot was taken.
+func (snap snapshot) contest(es emojiSet) contest {
+	con := contest{emojis: es, cfg: snap.Config}
+	for _, sp := range snap.Posts {
+		con.posts = append(con.posts, &post{
+			id:        sp.ID,
+			title:     sp.Title,
+			author:    sp.Author,
+			reactions: maps.Clone(sp.Reactions),
+		})
+	}
+	return con
+}
+
+func (snap snapshot) excludedVoters() map[string]bool {
+	ex := make(map[string]bool)
+	for _, u := range snap.Config.ExcludedVoters {
+		ex[u] = true
+	}
+	return ex
+}
+
+func sorted(s []string) []string {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
+
+func snapshotCollectionOf(guildID string) string {
+	return store.Collection(snapshotCollection, guildID)
+}
+
+// storedSnapshot keeps the exact bytes that were hashed: encoding the snapshot again would give
+// different ones as soon as its fields change, and make every earlier snapshot look tampered with.
+type storedSnapshot struct {
+	Data string `json:"data"`
+}
+
+// saveSnapshot stores the snapshot under its hash, which it returns.
+func saveSnapshot(snap snapshot) (string, error) {
+	data, h, err := snap.encode()
+	if err != nil {
+		return "", err
+	}
+	return h, store.Put(snapshotCollectionOf(snap.Config.GuildID), h, storedSnapshot{Data: string(data)})
+}
+
+// loadSnapshot returns the snapshot stored under the given hash, and whether its content still matches it.
+func loadSnapshot(guildID, hash string) (snap snapshot, found, valid bool, err error) {
+	var stored storedSnapshot
+	found, err = store.Get(snapshotCollectionOf(guildID), hash, &stored)
+	if err != nil || !found {
+		return snap, found, false, err
+	}
+	sum := sha256.Sum256([]byte(stored.Data))
+	valid = hex.EncodeToString(sum[:]) == hash
+	return snap, true, valid, json.Unmarshal([]byte(stored.Data), &snap)
+}
@@ -0,0 +1,84 @@
+package countvotes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/itizir/hrv/store"
+)
+
+func TestSnapshotHash(t *testing.T) {
+	cfg := contestConfig{GuildID: "g", Channel: "c", PlayedEmoji: "p", MainEmoji: "m"}
+	con := contest{cfg: cfg, posts: []*post{
+		{id: "100", title: "First", author: "a", reactions: map[string][]string{"m": {"x", "y"}, "p": {"y", "x"}, "s": nil}},
+		{id: "99", title: "Second", author: "b", reactions: map[string][]string{"m": {"z"}}},
+	}}
+	// the same data, gathered in another order
+	shuffled := contest{cfg: cfg, posts: []*post{
+		{id: "99", title: "Second", author: "b", reactions: map[string][]string{"m": {"z"}}},
+		{id: "100", title: "First", author: "a", reactions: map[string][]string{"p": {"x", "y"}, "m": {"y", "x"}}},
+	}}
+	opts := options{excludedVoters: []string{"w", "v"}}
+
+	snap := newSnapshot(con, opts)
+	if snap.Posts[0].ID != "99" {
+		t.Errorf("posts not sorted by submission time: %+v", snap.Posts)
+	}
+	_, h, err := snap.encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, h2, err := newSnapshot(shuffled, options{excludedVoters: []string{"v", "w"}}).encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h != h2 {
+		t.Errorf("same data hashed differently: %s and %s", h, h2)
+	}
+
+	con.posts[1].reactions["m"] = append(con.posts[1].reactions["m"], "x")
+	if _, h3, _ := newSnapshot(con, opts).encode(); h3 == h {
+		t.Error("different data hashed the same")
+	}
+}
+
+func TestSnapshotVerify(t *testing.T) {
+	store.Dir = t.TempDir()
+
+	con := contest{cfg: contestConfig{GuildID: "g", Channel: "c", PlayedEmoji: "p", MainEmoji: "m"}, posts: []*post{
+		{id: "1", title: "First", author: "a", reactions: map[string][]string{"m": {"x"}}},
+	}}
+	snap := newSnapshot(con, options{excludedVoters: []string{"v"}})
+	h, err := saveSnapshot(snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, found, valid, err := loadSnapshot("g", h)
+	if err != nil || !found || !valid {
+		t.Fatalf("loadSnapshot = %v, %v, %v", found, valid, err)
+	}
+	if !reflect.DeepEqual(got, snap) {
+		t.Errorf("loaded %+v, want %+v", got, snap)
+	}
+	if !got.excludedVoters()["v"] {
+		t.Errorf("excluded voters lost: %+v", got.Config)
+	}
+
+	if _, found, _, err := loadSnapshot("g", "unknown"); err != nil || found {
+		t.Errorf("unknown hash: found %v, err %v", found, err)
+	}
+
+	tampered := snap
+	tampered.Posts = []snapshotPost{{ID: "1", Title: "First", Author: "a", Reactions: map[string][]string{"m": {"y"}}}}
+	data, _, err := tampered.encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(snapshotCollectionOf("g"), h, storedSnapshot{Data: string(data)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, valid, err := loadSnapshot("g", h); err != nil || !found || valid {
+		t.Errorf("tampered snapshot: found %v, valid %v, err %v", found, valid, err)
+	}
+}
@@ -2,34 +2,29 @@ package countvotes
 
 import (
 	"cmp"
-	"fmt"
 	"slices"
 	"strings"
+
+	"golang.org/x/text/message"
 )
 
-// Checks for irregularities:
-// - no more than one submission per participant
-// - no voting on one's own submission
-// - only a single 'main' vote allowed per voter
-// - only as many 'secondary' votes allowed as number of contest entries
-// - max 2 'secondary' votes per submission per voter
-// - voters should mark submissions they have evaluated with the 'played' reaction
-func (con contest) validate(excludedVoters map[string]bool) []string {
-	type stats struct {
-		submissions    int
-		votesTotal     int
-		mainVotesTotal int
-		playedTotal    int
-		selfVote       bool
-		overVoted      []string
-		missingPlayed  []string
-	}
+// participantStats is what validation looks at for each participant, voter or contestant.
+type participantStats struct {
+	submissions    int
+	votesTotal     int
+	mainVotesTotal int
+	playedTotal    int
+	selfVote       bool
+	overVoted      []*post
+	missingPlayed  []*post
+}
 
-	participants := make(map[string]*stats)
-	getStats := func(p string) *stats {
+func (con contest) participantStats() map[string]*participantStats {
+	participants := make(map[string]*participantStats)
+	getStats := func(p string) *participantStats {
 		s, ok := participants[p]
 		if !ok {
-			s = &stats{}
+			s = &participantStats{}
 			participants[p] = s
 		}
 		return s
@@ -39,14 +34,14 @@ func (con contest) validate(excludedVoters map[string]bool) []string {
 		getStats(p.author).submissions++
 
 		hasPlayed := make(map[string]bool)
-		for _, u := range p.reactions[emojiPlayed] {
+		for _, u := range p.reactions[con.cfg.PlayedEmoji] {
 			hasPlayed[u] = true
 			getStats(u).playedTotal++
 		}
 
 		numVotesPost := make(map[string]int)
 		for k, voters := range p.reactions {
-			if k == emojiPlayed {
+			if k == con.cfg.PlayedEmoji {
 				continue
 			}
 			for _, voter := range voters {
@@ -55,68 +50,85 @@ func (con contest) validate(excludedVoters map[string]bool) []string {
 				if voter == p.author {
 					s.selfVote = true
 				}
-				if l := len(s.missingPlayed); !hasPlayed[voter] && (l == 0 || s.missingPlayed[l-1] != p.thread) {
-					s.missingPlayed = append(s.missingPlayed, p.thread)
+				if l := len(s.missingPlayed); !hasPlayed[voter] && (l == 0 || s.missingPlayed[l-1] != p) {
+					s.missingPlayed = append(s.missingPlayed, p)
 				}
 
-				if k == emojiMain {
+				if k == con.cfg.MainEmoji {
 					s.mainVotesTotal++
 				} else {
 					s.votesTotal++
 					numVotesPost[voter]++
-					if numVotesPost[voter] == 3 { // max 2 per submission
-						s.overVoted = append(s.overVoted, p.thread)
+					if numVotesPost[voter] == con.cfg.MaxVotesPerPost+1 {
+						s.overVoted = append(s.overVoted, p)
 					}
 				}
 			}
 		}
 	}
+	return participants
+}
 
-	mainVote := emojiMain
-	if e := knownEmojis[emojiMain]; e != nil {
-		mainVote = e.MessageFormat()
-	}
+// irregularity is everything a participant did wrong.
+type irregularity struct {
+	participant string
+	offenses    []offense
+}
 
-	playedReaction := emojiPlayed
-	if e := knownEmojis[emojiPlayed]; e != nil {
-		playedReaction = e.MessageFormat()
-	}
+type offenseKind int
 
-	var irregularities []string
-	for p, s := range participants {
+const (
+	offenseSubmissions offenseKind = iota
+	offenseSelfVote
+	offenseMainVotes
+	offenseVotes
+	offenseOverVoted
+	offenseMissingPlayed
+	offenseNoEffort
+)
+
+// offense is one of the rules a participant broke, with what it takes to describe it.
+type offense struct {
+	kind         offenseKind
+	count, limit int
+	posts        []*post // the submissions involved, if any
+}
+
+// same tells whether both offenses are the same, regardless of how the submissions involved are titled.
+func (o offense) same(other offense) bool {
+	return o.kind == other.kind && o.count == other.count && o.limit == other.limit &&
+		slices.EqualFunc(o.posts, other.posts, func(p, q *post) bool { return p.id == q.id })
+}
+
+func (irr irregularity) same(other irregularity) bool {
+	return irr.participant == other.participant && slices.EqualFunc(irr.offenses, other.offenses, offense.same)
+}
+
+// Checks for irregularities:
+// - no more than one submission per participant
+// - no voting on one's own submission
+// - only a single 'main' vote allowed per voter
+// - only as many 'secondary' votes allowed as number of contest entries
+// - max 'secondary' votes per submission per voter, as configured (2 by default)
+// - voters should mark submissions they have evaluated with the 'played' reaction
+func (con contest) irregularities(excludedVoters map[string]bool) []irregularity {
+	var irregularities []irregularity
+	for p, s := range con.participantStats() {
 		if excludedVoters[p] {
 			continue
 		}
-
-		var offenses []string
-		if s.submissions > 1 {
-			offenses = append(offenses, "made more than one submission")
-		}
-		if s.selfVote {
-			offenses = append(offenses, "voted for their own submission")
-		}
-		if s.mainVotesTotal > 1 {
-			offenses = append(offenses, fmt.Sprintf("gave out %d %s", s.mainVotesTotal, mainVote))
-		}
-		if s.votesTotal > len(con.posts) {
-			offenses = append(offenses, fmt.Sprintf("gave out %d instead of max %d votes overall", s.votesTotal, len(con.posts)))
-		}
-		if len(s.overVoted) > 0 {
-			offenses = append(offenses, fmt.Sprintf("gave out too many votes to %s", strings.Join(s.overVoted, ", ")))
-		}
-		if len(s.missingPlayed) > 0 {
-			offenses = append(offenses, fmt.Sprintf("voted without reacting with %s on %s", playedReaction, strings.Join(s.missingPlayed, ", ")))
-		}
-		if s.submissions > 0 && s.mainVotesTotal+s.votesTotal+s.playedTotal == 0 {
-			offenses = append(offenses, "seem to not have made any efforts in voting despite making a contest submission")
+		if offenses := con.offenses(s); len(offenses) > 0 {
+			irregularities = append(irregularities, irregularity{participant: p, offenses: offenses})
 		}
+	}
+	return irregularities
+}
 
-		if l := len(offenses); l == 0 {
-			continue
-		} else if l > 1 {
-			offenses[l-1] = "_and_ " + offenses[l-1]
-		}
-		irregularities = append(irregularities, fmt.Sprintf("%s is on the naughty list! They %s! 🙀", userMention(p), strings.Join(offenses, ", ")))
+// validate describes the irregularities, biggest offenders first.
+func (con contest) validate(pr *message.Printer, excludedVoters map[string]bool) []string {
+	var irregularities []string
+	for _, irr := range con.irregularities(excludedVoters) {
+		irregularities = append(irregularities, con.formatIrregularity(pr, irr))
 	}
 
 	// Make the ordering deterministic, but just to an approximate thing for biggest offenders first...
@@ -129,3 +141,63 @@ func (con contest) validate(excludedVoters map[string]bool) []string {
 
 	return irregularities
 }
+
+func (con contest) formatIrregularity(pr *message.Printer, irr irregularity) string {
+	var offenses []string
+	for _, o := range irr.offenses {
+		offenses = append(offenses, con.formatOffense(pr, o))
+	}
+	if l := len(offenses); l > 1 {
+		offenses[l-1] = pr.Sprintf("_and_ %s", offenses[l-1])
+	}
+	return pr.Sprintf("%s is on the naughty list! They %s! 🙀", userMention(irr.participant), strings.Join(offenses, ", "))
+}
+
+func (con contest) offenses(s *participantStats) []offense {
+	var offenses []offense
+	if s.submissions > 1 {
+		offenses = append(offenses, offense{kind: offenseSubmissions})
+	}
+	if s.selfVote {
+		offenses = append(offenses, offense{kind: offenseSelfVote})
+	}
+	if s.mainVotesTotal > 1 {
+		offenses = append(offenses, offense{kind: offenseMainVotes, count: s.mainVotesTotal})
+	}
+	if s.votesTotal > len(con.posts) {
+		offenses = append(offenses, offense{kind: offenseVotes, count: s.votesTotal, limit: len(con.posts)})
+	}
+	if len(s.overVoted) > 0 {
+		offenses = append(offenses, offense{kind: offenseOverVoted, posts: s.overVoted})
+	}
+	if len(s.missingPlayed) > 0 {
+		offenses = append(offenses, offense{kind: offenseMissingPlayed, posts: s.missingPlayed})
+	}
+	if s.submissions > 0 && s.mainVotesTotal+s.votesTotal+s.playedTotal == 0 {
+		offenses = append(offenses, offense{kind: offenseNoEffort})
+	}
+	return offenses
+}
+
+func (con contest) formatOffense(pr *message.Printer, o offense) string {
+	var titles []string
+	for _, p := range o.posts {
+		titles = append(titles, p.title)
+	}
+	switch o.kind {
+	case offenseSubmissions:
+		return pr.Sprintf("made more than one submission")
+	case offenseSelfVote:
+		return pr.Sprintf("voted for their own submission")
+	case offenseMainVotes:
+		return pr.Sprintf("gave out %d %s", o.count, con.emojis.format(con.cfg.MainEmoji))
+	case offenseVotes:
+		return pr.Sprintf("gave out %d instead of max %d votes overall", o.count, o.limit)
+	case offenseOverVoted:
+		return pr.Sprintf("gave out too many votes to %s", strings.Join(titles, ", "))
+	case offenseMissingPlayed:
+		return pr.Sprintf("voted without reacting with %s on %s", con.emojis.format(con.cfg.PlayedEmoji), strings.Join(titles, ", "))
+	default:
+		return pr.Sprintf("seem to not have made any efforts in voting despite making a contest submission")
+	}
+}
@@ -0,0 +1,65 @@
+package countvotes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+)
+
+const buttonReward = "reward"
+
+// eligibleVoters lists, sorted, the voters who played at least as many submissions as the contest requires,
+// without breaking any rule.
+func (con contest) eligibleVoters(excludedVoters map[string]bool) []string {
+	var voters []string
+	for u, s := range con.participantStats() {
+		if excludedVoters[u] || s.playedTotal < con.cfg.minPlayed() || len(con.offenses(s)) > 0 {
+			continue
+		}
+		voters = append(voters, u)
+	}
+	slices.Sort(voters)
+	return voters
+}
+
+// rewardVoters grants the voter role of the contest to every eligible voter, and describes how that went.
+func rewardVoters(s *discordgo.Session, p *message.Printer, cfg contestConfig, opts options, progress func(done, total int)) string {
+	if cfg.VoterRole == "" {
+		return p.Sprintf("No voter role configured for <#%s>: set one with `/%s %s`.", cfg.Channel, ApplicationAdminCommand.Name, adminCommandEdit)
+	}
+
+	con, excludedVoters, resp, ok := prepareContest(s, p, cfg, opts, progress)
+	if !ok {
+		return resp
+	}
+	voters := con.eligibleVoters(excludedVoters)
+	if len(voters) == 0 {
+		return resp + p.Sprintf("No voter in <#%s> played at least %d submissions without breaking any rule.", cfg.Channel, cfg.minPlayed())
+	}
+
+	granted := make([]bool, len(voters))
+	errs := forEachBounded(len(voters), func(i int) error {
+		if err := s.GuildMemberRoleAdd(cfg.GuildID, voters[i], cfg.VoterRole); err != nil {
+			return fmt.Errorf("%s: %w", userMention(voters[i]), err)
+		}
+		granted[i] = true
+		return nil
+	}, nil)
+
+	var rewarded []string
+	for i, u := range voters {
+		if granted[i] {
+			rewarded = append(rewarded, u)
+		}
+	}
+	if len(rewarded) > 0 {
+		resp += p.Sprintf("🗳️ Thanks for voting! <@&%s> granted to %s.", cfg.VoterRole, mentions(rewarded))
+	}
+	if len(errs) > 0 {
+		resp += "\n" + p.Sprintf("⚠️ Failed to grant the role to %d of %d voters:", len(errs), len(voters)) + "\n" + errors.Join(errs...).Error()
+	}
+	return resp
+}
@@ -0,0 +1,125 @@
+// Package i18n picks message printers matching the locale of Discord interactions,
+// and fills in command localizations at registration time.
+//
+// Translations are registered by the packages owning the strings, keyed by the English text,
+// so that untranslated strings simply fall back to English.
+package i18n
+
+import (
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+var (
+	supported = []language.Tag{language.English, language.French, language.German}
+	matcher   = language.NewMatcher(supported)
+
+	// command and option names live in their own catalog: short words like "rank" or "name"
+	// would otherwise clash with regular message keys.
+	names = catalog.NewBuilder(catalog.Fallback(language.English))
+)
+
+// Register adds translations of English messages to the given language.
+func Register(tag language.Tag, msgs map[string]string) {
+	for k, v := range msgs {
+		if err := message.SetString(tag, k, v); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterNames adds translations of command and option names to the given language.
+func RegisterNames(tag language.Tag, msgs map[string]string) {
+	for k, v := range msgs {
+		if err := names.SetString(tag, k, v); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Tag returns the best supported language for the given Discord locales, in order of preference.
+func Tag(locales ...discordgo.Locale) language.Tag {
+	var tags []language.Tag
+	for _, l := range locales {
+		if t, err := language.Parse(string(l)); err == nil {
+			tags = append(tags, t)
+		}
+	}
+	tag, _, _ := matcher.Match(tags...)
+	return tag
+}
+
+// Printer returns a printer for replies meant for the user who triggered the interaction.
+func Printer(i *discordgo.Interaction) *message.Printer {
+	locales := []discordgo.Locale{i.Locale}
+	if i.GuildLocale != nil {
+		locales = append(locales, *i.GuildLocale)
+	}
+	return message.NewPrinter(Tag(locales...))
+}
+
+// GuildPrinter returns a printer for messages meant for everyone in the guild.
+func GuildPrinter(i *discordgo.Interaction) *message.Printer {
+	var locales []discordgo.Locale
+	if i.GuildLocale != nil {
+		locales = append(locales, *i.GuildLocale)
+	}
+	locales = append(locales, i.Locale)
+	return message.NewPrinter(Tag(locales...))
+}
+
+// Default returns a printer for when no interaction is at hand.
+func Default() *message.Printer {
+	return message.NewPrinter(language.English)
+}
+
+var discordLocales = map[language.Tag][]discordgo.Locale{
+	language.French: {discordgo.French},
+	language.German: {discordgo.German},
+}
+
+// LocalizeCommand sets the name and description localizations of the command and its options,
+// for all the languages that have a translation available.
+func LocalizeCommand(cmd *discordgo.ApplicationCommand) {
+	nameLoc := make(map[discordgo.Locale]string)
+	descLoc := make(map[discordgo.Locale]string)
+	localize(cmd.Name, cmd.Description, nameLoc, descLoc)
+	if len(nameLoc) > 0 {
+		cmd.NameLocalizations = &nameLoc
+	}
+	if len(descLoc) > 0 {
+		cmd.DescriptionLocalizations = &descLoc
+	}
+
+	var localizeOptions func(opts []*discordgo.ApplicationCommandOption)
+	localizeOptions = func(opts []*discordgo.ApplicationCommandOption) {
+		for _, o := range opts {
+			if o.NameLocalizations == nil {
+				o.NameLocalizations = make(map[discordgo.Locale]string)
+			}
+			if o.DescriptionLocalizations == nil {
+				o.DescriptionLocalizations = make(map[discordgo.Locale]string)
+			}
+			localize(o.Name, o.Description, o.NameLocalizations, o.DescriptionLocalizations)
+			localizeOptions(o.Options)
+		}
+	}
+	localizeOptions(cmd.Options)
+}
+
+func localize(name, desc string, nameLoc, descLoc map[discordgo.Locale]string) {
+	for tag, locales := range discordLocales {
+		n := message.NewPrinter(tag, message.Catalog(names)).Sprintf(name)
+		d := message.NewPrinter(tag).Sprintf(desc)
+		for _, l := range locales {
+			if n != name {
+				nameLoc[l] = n
+			}
+			if d != desc {
+				descLoc[l] = d
+			}
+		}
+	}
+}
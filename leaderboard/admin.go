@@ -1,14 +1,16 @@
 package leaderboard
 
 import (
-	"errors"
 	"fmt"
-	"os"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+	"golang.org/x/text/message"
 )
 
 var (
+	zero = 0.
+
 	ApplicationAdminCommand = &discordgo.ApplicationCommand{
 		Type:        discordgo.ChatApplicationCommand,
 		Name:        "rank_admin",
@@ -20,19 +22,88 @@ var (
 				Description: "Remove a player rank entry from leaderboard",
 				Options: []*discordgo.ApplicationCommandOption{
 					{
-						Type:        discordgo.ApplicationCommandOptionString,
-						Name:        adminCommandArgKeyName,
-						Description: "Player name (prefix-matching)",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         adminCommandArgKeyName,
+						Description:  "Player name (prefix-matching)",
+						Autocomplete: true,
 					},
 					{
 						Type:        discordgo.ApplicationCommandOptionInteger,
 						Name:        adminCommandArgKeyRank,
 						Description: "Player rank",
 					},
+					{
+						Type:         discordgo.ApplicationCommandOptionInteger,
+						Name:         adminCommandArgKeySeason,
+						Description:  "Season number, defaults to latest",
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        adminCommandRebuild,
+				Description: "Render the stored leaderboard into its thread again",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        adminCommandArgKeyCompact,
+						Description: "Also delete the pages left unused at the end",
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionInteger,
+						Name:         adminCommandArgKeySeason,
+						Description:  "Season number, defaults to latest",
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        adminCommandResolve,
+				Description: "Resolve a conflict listed as needing attention",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         adminCommandArgKeyConflict,
+						Description:  "Conflict to resolve",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        adminCommandArgKeyAction,
+						Description: "keep_newest unranks older claims, shift moves them down, dismiss leaves things be",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: resolveKeepNewest, Value: resolveKeepNewest},
+							{Name: resolveShift, Value: resolveShift},
+							{Name: resolveDismiss, Value: resolveDismiss},
+						},
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionInteger,
+						Name:         adminCommandArgKeySeason,
+						Description:  "Season number, defaults to latest",
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        adminCommandStaleness,
+				Description: "Show or set when reported ranks go stale",
+				Options: []*discordgo.ApplicationCommandOption{
 					{
 						Type:        discordgo.ApplicationCommandOptionInteger,
-						Name:        adminCommandArgKeySeason,
-						Description: "Season number, defaults to latest",
+						Name:        adminCommandArgKeyDays,
+						Description: "Days until a rank goes stale, noticed on updates (or hourly in websocket mode); 0 for never",
+						MinValue:    &zero,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        adminCommandArgKeySeparate,
+						Description: "List stale ranks in their own section instead of just marking them",
 					},
 				},
 			},
@@ -51,66 +122,136 @@ var (
 			},
 		},
 	}
-
-	// export for easy override in testing environment
-	AdminID = os.Getenv("LEADERBOARD_ADMIN_ID")
 )
 
 const (
 	adminCommandDelete      = "delete"
+	adminCommandRebuild     = "rebuild"
+	adminCommandResolve     = "resolve"
+	adminCommandStaleness   = "staleness"
 	adminCommandStartSeason = "start"
 
-	adminCommandArgKeyName   = "name"
-	adminCommandArgKeyRank   = "rank"
-	adminCommandArgKeySeason = "season"
+	adminCommandArgKeyAction   = "action"
+	adminCommandArgKeyCompact  = "compact"
+	adminCommandArgKeyConflict = "conflict"
+	adminCommandArgKeyDays     = "days"
+	adminCommandArgKeyName     = "name"
+	adminCommandArgKeyRank     = "rank"
+	adminCommandArgKeySeason   = "season"
+	adminCommandArgKeySeparate = "separate"
 )
 
 func HandleAdmin(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	// for now insist it can only be me. better solution would be role-based enforced in Guild settings instead of done here in the handler
-	if i.Member == nil || i.Member.User.ID != AdminID {
-		return s.InteractionRespond(i.Interaction,
-			&discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("Unauthorised. Ask %s for help!", userMention(AdminID)),
-					Flags:   discordgo.MessageFlagsEphemeral,
-				},
-			})
-	}
+	p := i18n.Printer(i.Interaction)
 
 	if l := len(i.ApplicationCommandData().Options); l != 1 {
 		return fmt.Errorf("invalid options length in %s: %d", ApplicationAdminCommand.Name, l)
 	}
 	o := i.ApplicationCommandData().Options[0]
 
-	msg := "Not yet implemented!"
+	msg := p.Sprintf("Not yet implemented!")
 	switch o.Name {
 	case adminCommandDelete:
 		vals := optionsToDict(o.Options)
 		nameOrMention, _ := vals[adminCommandArgKeyName].(string)
-		if nameOrMention != "" {
-			id, err := findUserID(s, i.GuildID, nameOrMention)
-			if err == nil && id != "" {
-				nameOrMention = userMention(id)
+		rank, _ := vals[adminCommandArgKeyRank].(float64)
+		season, ok := vals[adminCommandArgKeySeason].(float64)
+		if !ok {
+			season = -1
+		}
+		if nameOrMention == "" && rank < 1 {
+			msg = p.Sprintf("Need at least a name or a rank.")
+			break
+		}
+		find := func() (*discordgo.Channel, string) {
+			if nameOrMention != "" {
+				id, err := findUserID(s, i.GuildID, nameOrMention)
+				if err == nil && id != "" {
+					nameOrMention = userMention(id)
+				}
 			}
+			return findSeasonThread(s, i, int(season))
 		}
-		rank, _ := vals[adminCommandArgKeyRank].(float64)
+		return queueUpdate(s, i, find, func(thread *discordgo.Channel) string {
+			if err := deleteEntry(s, i, thread, nameOrMention, int(rank)); err != nil {
+				return err.Error()
+			}
+			return p.Sprintf("OK!")
+		})
+	case adminCommandRebuild:
+		vals := optionsToDict(o.Options)
 		season, ok := vals[adminCommandArgKeySeason].(float64)
 		if !ok {
 			season = -1
 		}
-		if err := deleteEntry(s, i, nameOrMention, int(rank), int(season)); err != nil {
+		compact, _ := vals[adminCommandArgKeyCompact].(bool)
+		find := func() (*discordgo.Channel, string) { return findSeasonThread(s, i, int(season)) }
+		return queueUpdate(s, i, find, func(thread *discordgo.Channel) string {
+			if err := rebuildLeaderboard(s, i, thread, compact); err != nil {
+				return err.Error()
+			}
+			return p.Sprintf("OK!")
+		})
+	case adminCommandResolve:
+		vals := optionsToDict(o.Options)
+		key, _ := vals[adminCommandArgKeyConflict].(string)
+		action, _ := vals[adminCommandArgKeyAction].(string)
+		season, ok := vals[adminCommandArgKeySeason].(float64)
+		if !ok {
+			season = -1
+		}
+		find := func() (*discordgo.Channel, string) { return findSeasonThread(s, i, int(season)) }
+		return queueUpdate(s, i, find, func(thread *discordgo.Channel) string {
+			_, err := updateLeaderboard(s, thread, i18n.GuildPrinter(i.Interaction), func(ld *leaderboardData) error {
+				return ld.resolve(key, action)
+			})
+			if err != nil {
+				return err.Error()
+			}
+			return p.Sprintf("OK!")
+		})
+	case adminCommandStaleness:
+		vals := optionsToDict(o.Options)
+		st, err := loadSettings(i.GuildID)
+		if err != nil {
+			msg = err.Error()
+			break
+		}
+		if len(vals) == 0 {
+			msg = describeSettings(p, st)
+			break
+		}
+		if days, ok := vals[adminCommandArgKeyDays].(float64); ok {
+			st.StaleDays = int(days)
+		}
+		if separate, ok := vals[adminCommandArgKeySeparate].(bool); ok {
+			st.SeparateStale = separate
+		}
+		if err := saveSettings(i.GuildID, st); err != nil {
 			msg = err.Error()
-		} else {
-			msg = "OK!"
+			break
+		}
+		// apply to the current season right away, rather than at its next update
+		find := func() (*discordgo.Channel, string) {
+			thread, _, err := getSeasonThread(s, i.GuildID, i.AppID, -1)
+			if err != nil {
+				return nil, describeSettings(p, st)
+			}
+			return thread, ""
 		}
+		return queueUpdate(s, i, find, func(thread *discordgo.Channel) string {
+			if err := rebuildLeaderboard(s, i, thread, false); err != nil {
+				return err.Error()
+			}
+			return describeSettings(p, st)
+		})
 	case adminCommandStartSeason:
 		vals := optionsToDict(o.Options)
 		name, _ := vals[adminCommandArgKeyName].(string)
-		if err := createSeasonThread(s, i.GuildID, i.AppID, name); err != nil {
+		if err := createSeasonThread(s, i18n.GuildPrinter(i.Interaction), i.GuildID, i.AppID, name); err != nil {
 			msg = err.Error()
 		} else {
-			msg = "OK!"
+			msg = p.Sprintf("OK!")
 		}
 	}
 
@@ -124,6 +265,29 @@ func HandleAdmin(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 		})
 }
 
+// findSeasonThread finds the thread of a season to update, or says why it could not.
+func findSeasonThread(s *discordgo.Session, i *discordgo.InteractionCreate, season int) (*discordgo.Channel, string) {
+	thread, _, err := getSeasonThread(s, i.GuildID, i.AppID, season)
+	if err != nil {
+		return nil, err.Error()
+	}
+	return thread, ""
+}
+
+func describeSettings(p *message.Printer, st settings) string {
+	if st.StaleDays <= 0 {
+		return p.Sprintf("Reported ranks never go stale.")
+	}
+	msg := p.Sprintf("Ranks reported more than %d days ago are marked with %s, and the players asked to confirm them.", st.StaleDays, staleMarker)
+	if st.SeparateStale {
+		msg = p.Sprintf("Ranks reported more than %d days ago are listed in their own section, and the players asked to confirm them.", st.StaleDays)
+	}
+	if checkingStaleness.Load() {
+		return msg + " " + p.Sprintf("Ranks going stale are looked for every hour.")
+	}
+	return msg + " " + p.Sprintf("Ranks going stale are only noticed when the leaderboard is updated.")
+}
+
 func optionsToDict(opts []*discordgo.ApplicationCommandInteractionDataOption) map[string]any {
 	vals := make(map[string]any)
 	for _, o := range opts {
@@ -132,27 +296,23 @@ func optionsToDict(opts []*discordgo.ApplicationCommandInteractionDataOption) ma
 	return vals
 }
 
-func deleteEntry(s *discordgo.Session, i *discordgo.InteractionCreate, nameOrMention string, rank, season int) error {
-	if nameOrMention == "" && rank < 1 {
-		return errors.New("need at least name or rank")
-	}
+func deleteEntry(s *discordgo.Session, i *discordgo.InteractionCreate, thread *discordgo.Channel, nameOrMention string, rank int) error {
+	_, err := updateLeaderboard(s, thread, i18n.GuildPrinter(i.Interaction), func(ld *leaderboardData) error {
+		ld.removeEntries(nameOrMention, rank)
+		return nil
+	})
+	return err
+}
 
-	thread, _, err := getSeasonThread(s, i.GuildID, i.AppID, season)
+// rebuildLeaderboard renders the stored leaderboard in the thread again,
+// from the first page on, and optionally deletes the pages no longer needed.
+func rebuildLeaderboard(s *discordgo.Session, i *discordgo.InteractionCreate, thread *discordgo.Channel, compact bool) error {
+	ld, err := updateLeaderboard(s, thread, i18n.GuildPrinter(i.Interaction), func(*leaderboardData) error { return nil })
 	if err != nil {
 		return err
 	}
-
-	if !yuckyMutex.TryLock() {
-		return fmt.Errorf("%s is currently busy, sorry; try again", userMention(i.AppID))
+	if compact {
+		return ld.compact(s)
 	}
-	defer yuckyMutex.Unlock()
-
-	ld, err := getLeaderboardData(s, thread)
-	if err != nil {
-		return err
-	}
-
-	ld.removeEntries(nameOrMention, rank)
-
-	return ld.updateMessages(s)
+	return nil
 }
@@ -0,0 +1,206 @@
+package leaderboard
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+)
+
+const (
+	maxChoices          = 25 // most Discord accepts for autocomplete
+	maxChoiceNameLength = 100
+)
+
+// AdminAutocomplete suggests season numbers and, for deletion, the players on the leaderboard of the chosen season.
+func AdminAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 1 {
+		o := data.Options[0]
+		vals := optionsToDict(o.Options)
+		for _, opt := range o.Options {
+			if !opt.Focused {
+				continue
+			}
+			var err error
+			switch opt.Name {
+			case adminCommandArgKeySeason:
+				choices, err = seasonChoices(s, i.GuildID, i.AppID, fmt.Sprint(opt.Value))
+			case adminCommandArgKeyName:
+				// the season is only known if already filled in, which Discord sends as text while typing
+				season, convErr := strconv.Atoi(fmt.Sprint(vals[adminCommandArgKeySeason]))
+				if convErr != nil {
+					season = -1
+				}
+				query, _ := opt.Value.(string)
+				choices, err = playerChoices(s, i.GuildID, i.AppID, season, query)
+			case adminCommandArgKeyConflict:
+				season, convErr := strconv.Atoi(fmt.Sprint(vals[adminCommandArgKeySeason]))
+				if convErr != nil {
+					season = -1
+				}
+				query, _ := opt.Value.(string)
+				choices, err = conflictChoices(s, i, season, query)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	})
+}
+
+// Autocomplete suggests the players on the leaderboard of the latest season.
+func Autocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 1 {
+		for _, opt := range data.Options[0].Options {
+			if !opt.Focused || opt.Name != commandArgKeyPlayer {
+				continue
+			}
+			query, _ := opt.Value.(string)
+			var err error
+			if choices, err = playerChoices(s, i.GuildID, i.AppID, -1, query); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	})
+}
+
+func seasonChoices(s *discordgo.Session, guildID, appID, query string) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	seasons, err := seasonThreads(s, guildID, appID)
+	if err != nil {
+		return nil, err
+	}
+	var numbers []int
+	for n := range seasons {
+		if strings.HasPrefix(strconv.Itoa(n), query) {
+			numbers = append(numbers, n)
+		}
+	}
+	// latest first
+	slices.Sort(numbers)
+	slices.Reverse(numbers)
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, n := range numbers[:min(len(numbers), maxChoices)] {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: seasons[n].Name, Value: n})
+	}
+	return choices, nil
+}
+
+// playerChoices only knows the names of players reported by user ID if the query matches them, or if cached:
+// looking every one of them up would take longer than Discord waits for suggestions.
+func playerChoices(s *discordgo.Session, guildID, appID string, season int, query string) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	thread, _, err := getSeasonThread(s, guildID, appID, season)
+	if err != nil {
+		return nil, err
+	}
+	b, err := loadBoard(s, thread)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	if query != "" {
+		mem, err := s.GuildMembersSearch(guildID, query, 100)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range mem {
+			names[m.User.ID] = memberName(m)
+		}
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	seen := make(map[string]bool)
+	for _, e := range slices.Concat(b.Ranked, b.Unordered) {
+		name, value := e.name, e.player()
+		matches := strings.Contains(strings.ToLower(name), strings.ToLower(query))
+		if e.userID != "" {
+			name = e.userID
+			if n, ok := names[e.userID]; ok {
+				name, matches = n, true
+			} else if m, err := s.State.Member(guildID, e.userID); err == nil {
+				name = memberName(m)
+				matches = strings.Contains(strings.ToLower(name), strings.ToLower(query))
+			} else {
+				matches = query == ""
+			}
+		}
+		if !matches || seen[value] {
+			continue
+		}
+		seen[value] = true
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: name, Value: value})
+		if len(choices) == maxChoices {
+			break
+		}
+	}
+	return choices, nil
+}
+
+// conflictChoices names players from the state cache only, as there may be many of them.
+func conflictChoices(s *discordgo.Session, i *discordgo.InteractionCreate, season int, query string) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	thread, _, err := getSeasonThread(s, i.GuildID, i.AppID, season)
+	if err != nil {
+		return nil, err
+	}
+	b, err := loadBoard(s, thread)
+	if err != nil {
+		return nil, err
+	}
+
+	p := i18n.Printer(i.Interaction)
+	name := func(e entry) string {
+		if e.userID == "" {
+			return e.name
+		}
+		if m, err := s.State.Member(i.GuildID, e.userID); err == nil {
+			return memberName(m)
+		}
+		return e.userID
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, c := range b.conflicts() {
+		desc := c.format(p, name)
+		if !strings.Contains(strings.ToLower(desc), strings.ToLower(query)) {
+			continue
+		}
+		if r := []rune(desc); len(r) > maxChoiceNameLength {
+			desc = string(r[:maxChoiceNameLength-1]) + "…"
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: desc, Value: c.key()})
+		if len(choices) == maxChoices {
+			break
+		}
+	}
+	return choices, nil
+}
+
+func memberName(m *discordgo.Member) string {
+	if m.Nick != "" {
+		return m.Nick
+	}
+	if m.User.GlobalName != "" {
+		return m.User.GlobalName
+	}
+	return m.User.Username
+}
@@ -2,36 +2,101 @@ package leaderboard
 
 import (
 	"fmt"
-	"sync"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
 )
 
 var (
 	ApplicationCommand = &discordgo.ApplicationCommand{
 		Type:        discordgo.ChatApplicationCommand,
 		Name:        "rank",
-		Description: "Report player rank for leaderboard",
+		Description: "Master rank leaderboard",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        commandReport,
+				Description: "Report player rank for leaderboard",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        commandHistory,
+				Description: "Show the ranks reported for a player over the seasons",
+				Options:     []*discordgo.ApplicationCommandOption{playerOption},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        commandShow,
+				Description: "Show where a player stands, now and in past seasons",
+				Options:     []*discordgo.ApplicationCommandOption{playerOption},
+			},
+		},
 	}
 
-	// the fetch messages-update messages operation is very much non-atomic, so races could be bad
-	// don't want to go overboard trying to synchronise more elegantly...
-	yuckyMutex sync.Mutex
+	playerOption = &discordgo.ApplicationCommandOption{
+		Type:         discordgo.ApplicationCommandOptionString,
+		Name:         commandArgKeyPlayer,
+		Description:  "Player, defaults to yourself",
+		Autocomplete: true,
+	}
+)
+
+const (
+	commandReport  = "report"
+	commandHistory = "history"
+	commandShow    = "show"
+
+	commandArgKeyPlayer = "player"
 )
 
 func Handle(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.Printer(i.Interaction)
 	msg := ""
 	switch i.Type {
 	case discordgo.InteractionApplicationCommand:
-		if err := presentModal(s, i); err != nil {
-			msg = err.Error()
+		if l := len(i.ApplicationCommandData().Options); l != 1 {
+			return fmt.Errorf("invalid options length in %s: %d", ApplicationCommand.Name, l)
+		}
+		o := i.ApplicationCommandData().Options[0]
+		vals := optionsToDict(o.Options)
+		switch o.Name {
+		case commandReport:
+			if err := presentModal(s, i); err != nil {
+				msg = err.Error()
+			}
+		case commandHistory:
+			query, _ := vals[commandArgKeyPlayer].(string)
+			var err error
+			if msg, err = playerHistory(s, i, query); err != nil {
+				msg = err.Error()
+			}
+		case commandShow:
+			query, _ := vals[commandArgKeyPlayer].(string)
+			var err error
+			if msg, err = playerProfile(s, i, query); err != nil {
+				msg = err.Error()
+			}
 		}
 	case discordgo.InteractionModalSubmit:
-		if id, err := editLeaderboard(s, i); err != nil {
-			msg = fmt.Sprintf("Failed to edit leaderboard: %v.", err)
-		} else {
-			msg = fmt.Sprintf("Leaderboard %s successfully edited.", channelMention(id))
+		var ent entry
+		find := func() (*discordgo.Channel, string) {
+			var season int
+			var err error
+			if ent, season, err = parseModalInput(s, i); err != nil {
+				return nil, p.Sprintf("Failed to edit leaderboard: %v.", err)
+			}
+			thread, _, err := getSeasonThread(s, i.GuildID, i.AppID, season)
+			if err != nil {
+				return nil, p.Sprintf("Failed to edit leaderboard: %v.", err)
+			}
+			return thread, ""
 		}
+		return queueUpdate(s, i, find, func(thread *discordgo.Channel) string {
+			if err := editLeaderboard(s, i, thread, ent); err != nil {
+				return p.Sprintf("Failed to edit leaderboard: %v.", err)
+			}
+			return p.Sprintf("Leaderboard %s successfully edited.", channelMention(thread.ID))
+		})
 	default:
 		return fmt.Errorf("unhandled interaction type: %v", i.Type)
 	}
@@ -0,0 +1,175 @@
+package leaderboard
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/message"
+)
+
+const (
+	resolveKeepNewest = "keep_newest"
+	resolveShift      = "shift"
+	resolveDismiss    = "dismiss"
+)
+
+type conflictKind int
+
+const (
+	rankCollision conflictKind = iota
+	pointsOutOfOrder
+)
+
+// conflict is either several players claiming the same rank, or a player ranked above another despite fewer points.
+type conflict struct {
+	kind conflictKind
+	// for collisions all claimants, otherwise the better ranked player first
+	entries []entry
+}
+
+// key identifies the conflict for as long as none of the entries involved changes.
+func (c conflict) key() string {
+	h := sha256.New()
+	fmt.Fprint(h, c.kind)
+	for _, e := range c.entries {
+		fmt.Fprintf(h, "|%s|%d|%d", e.player(), e.rank, e.timestamp)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+func (c conflict) format(p *message.Printer, name func(entry) string) string {
+	if c.kind == rankCollision {
+		var names []string
+		for _, e := range c.entries {
+			names = append(names, name(e))
+		}
+		return p.Sprintf("%s all claim rank #%d", strings.Join(names, ", "), c.entries[0].rank)
+	}
+	a, b := c.entries[0], c.entries[1]
+	return p.Sprintf("%s is ranked #%d with fewer points than %s, ranked #%d", name(a), a.rank, name(b), b.rank)
+}
+
+// conflicts lists what looks wrong with the leaderboard and was not dismissed yet.
+func (b board) conflicts() []conflict {
+	return slices.DeleteFunc(b.allConflicts(), func(c conflict) bool { return slices.Contains(b.Dismissed, c.key()) })
+}
+
+// allConflicts lists what looks wrong with the leaderboard. Points are only compared
+// between players next to each other among those with known points.
+func (b board) allConflicts() []conflict {
+	var res []conflict
+
+	byRank := make(map[int][]entry)
+	var ranks []int
+	for _, e := range b.Ranked {
+		if e.rank == 0 {
+			continue
+		}
+		if len(byRank[e.rank]) == 0 {
+			ranks = append(ranks, e.rank)
+		}
+		byRank[e.rank] = append(byRank[e.rank], e)
+	}
+	slices.Sort(ranks)
+	for _, r := range ranks {
+		if len(byRank[r]) > 1 {
+			res = append(res, conflict{kind: rankCollision, entries: byRank[r]})
+		}
+	}
+
+	var withPoints []entry
+	for _, e := range b.Ranked {
+		if e.rank > 0 && e.points > 0 {
+			withPoints = append(withPoints, e)
+		}
+	}
+	slices.SortStableFunc(withPoints, func(a, b entry) int { return cmp.Compare(a.rank, b.rank) })
+	for k := 1; k < len(withPoints); k++ {
+		a, b := withPoints[k-1], withPoints[k]
+		if a.rank < b.rank && a.points < b.points {
+			res = append(res, conflict{kind: pointsOutOfOrder, entries: []entry{a, b}})
+		}
+	}
+
+	return res
+}
+
+// resolve settles a conflict, by moving the older claims to the other masters, by shifting them
+// down along with the players right below, or by leaving things as they are.
+func (b *board) resolve(key, action string) error {
+	all := b.conflicts()
+	k := slices.IndexFunc(all, func(c conflict) bool { return c.key() == key })
+	if k < 0 {
+		return errors.New("no such conflict, it may have been resolved already")
+	}
+	c := all[k]
+
+	newest := slices.MaxFunc(c.entries, func(a, b entry) int { return cmp.Compare(a.timestamp, b.timestamp) })
+	switch action {
+	case resolveKeepNewest:
+		for _, e := range c.entries {
+			if !e.samePlayer(newest) {
+				b.unrank(e)
+			}
+		}
+	case resolveShift:
+		if c.kind != rankCollision {
+			return errors.New("only players claiming the same rank can be shifted")
+		}
+		b.shiftDown(c.entries[0].rank, newest)
+	case resolveDismiss:
+		b.Dismissed = append(b.Dismissed, key)
+	default:
+		return fmt.Errorf("unknown action %q", action)
+	}
+
+	// forget about dismissed conflicts that no longer exist
+	var keys []string
+	for _, c := range b.allConflicts() {
+		keys = append(keys, c.key())
+	}
+	b.Dismissed = slices.DeleteFunc(b.Dismissed, func(k string) bool { return !slices.Contains(keys, k) })
+	return nil
+}
+
+// unrank moves a ranked player to the other masters, keeping what was reported.
+func (b *board) unrank(e entry) {
+	b.Ranked = slices.DeleteFunc(b.Ranked, e.samePlayer)
+	if !slices.ContainsFunc(b.Unordered, e.samePlayer) {
+		e.rank = 0
+		b.Unordered = append(b.Unordered, e)
+	}
+}
+
+// shiftDown keeps the newest claim of the rank, and moves the others down by one,
+// along with the players below them until there is a gap in the ranks to absorb the shift.
+func (b *board) shiftDown(rank int, newest entry) {
+	slices.SortStableFunc(b.Ranked, func(x, y entry) int {
+		if c := cmp.Compare(x.rank, y.rank); c != 0 || x.rank != rank {
+			return c
+		}
+		// the newest claim first, the others by how recent they are
+		switch {
+		case x.samePlayer(newest):
+			return -1
+		case y.samePlayer(newest):
+			return 1
+		}
+		return cmp.Compare(y.timestamp, x.timestamp)
+	})
+
+	k := slices.IndexFunc(b.Ranked, newest.samePlayer)
+	prev := rank
+	for k++; k < len(b.Ranked); k++ {
+		if b.Ranked[k].rank > prev {
+			break
+		}
+		b.Ranked[k].rank = prev + 1
+		prev = b.Ranked[k].rank
+	}
+}
@@ -0,0 +1,121 @@
+package leaderboard
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestConflicts(t *testing.T) {
+	b := board{Ranked: []entry{
+		{userID: "111", rank: 1, points: 100, timestamp: 1},
+		{userID: "222", rank: 2, points: 90, timestamp: 2},
+		{userID: "333", rank: 2, timestamp: 3},
+		{userID: "444", rank: 3},
+		{userID: "555", rank: 4, points: 95, timestamp: 4},
+		{userID: "666", rank: 0, points: 500},
+	}}
+	all := b.allConflicts()
+	if len(all) != 2 {
+		t.Fatalf("got %d conflicts, want 2: %+v", len(all), all)
+	}
+	if c := all[0]; c.kind != rankCollision || !slices.Equal(players(c.entries), []string{"<@222>", "<@333>"}) {
+		t.Errorf("first conflict = %+v, want the claims of rank 2", c)
+	}
+	// points are only compared between players with known points
+	if c := all[1]; c.kind != pointsOutOfOrder || !slices.Equal(players(c.entries), []string{"<@222>", "<@555>"}) {
+		t.Errorf("second conflict = %+v, want #2 with fewer points than #4", c)
+	}
+
+	if all[0].key() == all[1].key() {
+		t.Error("different conflicts with the same key")
+	}
+	key := all[0].key()
+	if b.allConflicts()[0].key() != key {
+		t.Error("key of an unchanged conflict changed")
+	}
+	b.Ranked[2].timestamp = 5
+	if b.allConflicts()[0].key() == key {
+		t.Error("key unchanged despite a new report")
+	}
+
+	b.Dismissed = []string{b.allConflicts()[1].key()}
+	if got := b.conflicts(); len(got) != 1 || got[0].kind != rankCollision {
+		t.Errorf("conflicts = %+v, want the dismissed one left out", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	collision := func() board {
+		return board{Ranked: []entry{
+			{userID: "111", rank: 1},
+			{userID: "222", rank: 2, timestamp: 1},
+			{userID: "333", rank: 2, timestamp: 3},
+			{userID: "444", rank: 2, timestamp: 2},
+			{userID: "555", rank: 3},
+			{userID: "666", rank: 4},
+			{userID: "777", rank: 6},
+		}}
+	}
+	ranks := func(b board) map[string]int {
+		res := make(map[string]int)
+		for _, e := range b.Ranked {
+			res[e.player()] = e.rank
+		}
+		return res
+	}
+
+	b := collision()
+	if err := b.resolve(b.conflicts()[0].key(), resolveKeepNewest); err != nil {
+		t.Fatal(err)
+	}
+	if got := ranks(b); got["<@333>"] != 2 || len(got) != 5 {
+		t.Errorf("keep newest: ranked %v", got)
+	}
+	if got := players(b.Unordered); !slices.Equal(got, []string{"<@222>", "<@444>"}) {
+		t.Errorf("keep newest: unordered %v", got)
+	}
+
+	b = collision()
+	if err := b.resolve(b.conflicts()[0].key(), resolveShift); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"<@111>": 1, "<@333>": 2, "<@444>": 3, "<@222>": 4, "<@555>": 5, "<@666>": 6, "<@777>": 7}
+	if got := ranks(b); !maps.Equal(got, want) {
+		t.Errorf("shift: ranked %v, want %v", got, want)
+	}
+	if len(b.conflicts()) != 0 {
+		t.Errorf("shift: conflicts left %+v", b.conflicts())
+	}
+
+	b = collision()
+	key := b.conflicts()[0].key()
+	if err := b.resolve(key, resolveDismiss); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.conflicts()) != 0 || !slices.Equal(b.Dismissed, []string{key}) {
+		t.Errorf("dismiss: conflicts %+v, dismissed %v", b.conflicts(), b.Dismissed)
+	}
+	if err := b.resolve(key, resolveDismiss); err == nil {
+		t.Error("resolving a dismissed conflict again should fail")
+	}
+	// once the conflict changes, it is a new one and its dismissal is forgotten
+	b.Ranked[2].timestamp = 4
+	if len(b.conflicts()) != 1 {
+		t.Fatalf("conflicts %+v, want the changed one back", b.conflicts())
+	}
+	if err := b.resolve(b.conflicts()[0].key(), resolveKeepNewest); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Dismissed) != 0 {
+		t.Errorf("dismissed %v, want stale keys forgotten", b.Dismissed)
+	}
+
+	b = board{Ranked: []entry{{userID: "111", rank: 1, points: 10}, {userID: "222", rank: 2, points: 20}}}
+	if err := b.resolve(b.conflicts()[0].key(), resolveShift); err == nil {
+		t.Error("shifting players out of order by points should fail")
+	}
+	if err := b.resolve(b.conflicts()[0].key(), "unknown"); err == nil {
+		t.Error("unknown actions should fail")
+	}
+}
@@ -0,0 +1,158 @@
+package leaderboard
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+	"golang.org/x/text/message"
+)
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// resolvePlayer turns what was typed in a player option into an entry to compare others with:
+// a mention, a unique match among the members of the guild, or else a name. Empty means the caller.
+func resolvePlayer(s *discordgo.Session, i *discordgo.InteractionCreate, query string) (entry, error) {
+	if query == "" {
+		return entry{userID: i.Member.User.ID}, nil
+	}
+	if id, ok := mentionedID(query); ok {
+		return entry{userID: id}, nil
+	}
+	id, err := findUserID(s, i.GuildID, query)
+	if err != nil {
+		return entry{}, err
+	}
+	if id != "" {
+		return entry{userID: id}, nil
+	}
+	return entry{name: query}, nil
+}
+
+// reportsOf returns the reports accepted for the player, oldest first.
+// Leaderboards imported from their messages only know of the latest one.
+func (b board) reportsOf(player entry) []entry {
+	var reports []entry
+	for _, e := range b.History {
+		if e.samePlayer(player) {
+			reports = append(reports, e)
+		}
+	}
+	if len(reports) > 0 {
+		return reports
+	}
+	for _, e := range slices.Concat(b.Ranked, b.Unordered) {
+		if e.samePlayer(player) {
+			return []entry{e}
+		}
+	}
+	return nil
+}
+
+// playerHistory describes the reports for a player in every season, latest first.
+func playerHistory(s *discordgo.Session, i *discordgo.InteractionCreate, query string) (string, error) {
+	p := i18n.Printer(i.Interaction)
+
+	player, err := resolvePlayer(s, i, query)
+	if err != nil {
+		return "", err
+	}
+	seasons, err := allSeasonThreads(s, i.GuildID, i.AppID)
+	if err != nil {
+		return "", err
+	}
+	msg := p.Sprintf("Rank history of %s:", player.player())
+	found := false
+	for _, n := range latestFirst(seasons) {
+		b, err := loadBoard(s, seasons[n])
+		if err != nil {
+			return "", err
+		}
+		reports := b.reportsOf(player)
+		if len(reports) == 0 {
+			continue
+		}
+		found = true
+
+		section := "\n\n**" + seasons[n].Name + "** " + sparkline(reports)
+		for k, r := range reports {
+			section += "\n- " + formatReport(p, r, reports[:k])
+		}
+		if len(msg)+len(section) > discordMessageCharacterLimit-len("\n…") {
+			msg += "\n…"
+			break
+		}
+		msg += section
+	}
+	if !found {
+		return p.Sprintf("No rank reported for %s yet.", player.player()), nil
+	}
+	return msg, nil
+}
+
+// formatReport describes a report, with how it compares to the latest earlier ones giving a rank and points.
+func formatReport(p *message.Printer, r entry, earlier []entry) string {
+	when := "?"
+	if r.timestamp > 0 {
+		when = fmt.Sprintf("<t:%d:d>", r.timestamp)
+	}
+	rank := p.Sprintf("other master")
+	if r.rank > 0 {
+		rank = fmt.Sprintf("#%d", r.rank)
+	}
+	line := fmt.Sprintf("%s — %s %s", when, rank, r.formatPoints(p))
+
+	if prev, ok := latest(earlier, func(e entry) bool { return e.rank > 0 }); ok && r.rank > 0 {
+		if d := prev.rank - r.rank; d > 0 {
+			line += fmt.Sprintf(" ▲%d", d)
+		} else if d < 0 {
+			line += fmt.Sprintf(" ▼%d", -d)
+		}
+	}
+	if prev, ok := latest(earlier, func(e entry) bool { return e.points > 0 }); ok && r.points > 0 && prev.points != r.points {
+		line += " " + p.Sprintf("%+d", r.points-prev.points)
+	}
+	return line
+}
+
+func latest(reports []entry, f func(entry) bool) (entry, bool) {
+	for k := len(reports) - 1; k >= 0; k-- {
+		if f(reports[k]) {
+			return reports[k], true
+		}
+	}
+	return entry{}, false
+}
+
+// sparkline draws the ranks reported, the higher the bar the better the rank.
+// Reports without a known rank show as gaps.
+func sparkline(reports []entry) string {
+	best, worst := 0, 0
+	for _, r := range reports {
+		if r.rank == 0 {
+			continue
+		}
+		if best == 0 || r.rank < best {
+			best = r.rank
+		}
+		worst = max(worst, r.rank)
+	}
+	if best == 0 || len(reports) < 2 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, r := range reports {
+		switch {
+		case r.rank == 0:
+			b.WriteRune(' ')
+		case best == worst:
+			b.WriteRune(sparkBars[len(sparkBars)-1])
+		default:
+			b.WriteRune(sparkBars[(worst-r.rank)*(len(sparkBars)-1)/(worst-best)])
+		}
+	}
+	return "`" + b.String() + "`"
+}
@@ -1,14 +1,19 @@
 package leaderboard
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"log"
+	"math/rand/v2"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+	"golang.org/x/text/message"
 )
 
 const (
@@ -17,119 +22,309 @@ const (
 	discordMessageCharacterLimit = 2000
 )
 
-// returns the ID of the leaderboard channel
-func editLeaderboard(s *discordgo.Session, i *discordgo.InteractionCreate) (string, error) {
-	ent, season, err := parseModalInput(s, i)
-	if err != nil {
-		return "", err
-	}
+// editLeaderboard applies a report to the leaderboard in the thread.
+func editLeaderboard(s *discordgo.Session, i *discordgo.InteractionCreate, thread *discordgo.Channel, ent entry) error {
+	_, err := updateLeaderboard(s, thread, i18n.GuildPrinter(i.Interaction), func(ld *leaderboardData) error {
+		return ld.addEntry(ent)
+	})
+	return err
+}
 
-	thread, _, err := getSeasonThread(s, i.GuildID, i.AppID, season)
-	if err != nil {
-		return "", err
-	}
+// how many times an update is attempted while other instances of the bot keep changing the leaderboard
+const maxUpdateAttempts = 5
 
-	if !yuckyMutex.TryLock() {
-		return "", fmt.Errorf("%s is currently busy, sorry; try again", userMention(i.AppID))
+// updateLeaderboard modifies the leaderboard in the thread, saves it, and renders it.
+// If another instance of the bot changed it before it was saved, the update starts over from what that one left;
+// once saved, only rendering it is tried again.
+func updateLeaderboard(s *discordgo.Session, thread *discordgo.Channel, p *message.Printer, modify func(*leaderboardData) error) (leaderboardData, error) {
+	retry := func(attempt int) {
+		log.Printf("leaderboard %v changed concurrently, trying again (attempt %d)", thread.ID, attempt)
+		time.Sleep(time.Duration(attempt) * time.Duration(250+rand.IntN(250)) * time.Millisecond)
 	}
-	defer yuckyMutex.Unlock()
 
-	ld, err := getLeaderboardData(s, thread)
-	if err != nil {
-		log.Printf("failed to fetch leaderboard data from channel %v: %v", thread.ID, err)
-		return "", errors.New("failed to fetch messages")
-	}
+	var ld leaderboardData
+	for attempt := 1; ; attempt++ {
+		var err error
+		ld, err = getLeaderboardData(s, thread)
+		if errors.Is(err, errConflict) && attempt < maxUpdateAttempts {
+			continue
+		} else if err != nil {
+			log.Printf("failed to fetch leaderboard data from channel %v: %v", thread.ID, err)
+			return ld, errors.New("failed to fetch messages")
+		}
+		ld.printer = p
+
+		if err := modify(&ld); err != nil {
+			return ld, err
+		}
 
-	if err := ld.addEntry(ent); err != nil {
-		return "", err
+		err = ld.checkUnchanged(s)
+		if err == nil {
+			err = ld.save()
+		}
+		if errors.Is(err, errConflict) && attempt < maxUpdateAttempts {
+			retry(attempt)
+			continue
+		} else if err != nil {
+			log.Printf("failed to save leaderboard %v: %v", thread.ID, err)
+			return ld, errors.New("failed to save leaderboard")
+		}
+		break
 	}
 
-	if err := ld.updateMessages(s); err != nil {
-		log.Printf("failed to edit leaderboard %v: %v", thread.ID, err)
-		return "", errors.New("failed to edit leaderboard message")
+	for attempt := 1; ; attempt++ {
+		err := ld.updateMessages(s)
+		// remember the pages as rendered, even partly, to tell the edits of this instance from those of others
+		if saveErr := ld.save(); saveErr != nil {
+			log.Printf("failed to save pages of leaderboard %v: %v", thread.ID, saveErr)
+		}
+		if errors.Is(err, errConflict) && attempt < maxUpdateAttempts {
+			retry(attempt)
+			if err = ld.reloadPages(s); err == nil {
+				continue
+			}
+		}
+		if err != nil {
+			log.Printf("failed to edit leaderboard %v: %v", thread.ID, err)
+			return ld, errors.New("failed to edit leaderboard message")
+		}
+		break
 	}
 
-	return thread.ID, nil
+	ld.notifyStale(s, time.Now())
+	return ld, nil
 }
 
 type discordMessage struct {
 	id      string
 	content string
+	edited  time.Time
 }
 
 type leaderboardData struct {
-	ranked    []string
-	unordered []string
+	board
 
+	guildID   string
 	channelID string
 	msgs      []discordMessage
+	settings  settings
 
-	appID string
+	appID   string
+	printer *message.Printer
 }
 
+// getLeaderboardData fetches the messages of the thread to render the leaderboard into, along with the leaderboard itself.
+// Leaderboards predating the store are imported from those messages the first time, and imported again
+// whenever another instance of the bot, with a store of its own, rendered them since.
 func getLeaderboardData(s *discordgo.Session, thread *discordgo.Channel) (leaderboardData, error) {
-	ld := leaderboardData{channelID: thread.ID, appID: thread.OwnerID}
+	ld := leaderboardData{guildID: thread.GuildID, channelID: thread.ID, appID: thread.OwnerID, printer: i18n.Default()}
+
+	b, ok, err := Boards.Load(thread.GuildID, thread.ID)
+	if err != nil {
+		return ld, err
+	}
+	if ld.settings, err = loadSettings(thread.GuildID); err != nil {
+		return ld, err
+	}
+
+	var msgs []*discordgo.Message
+	if len(b.Pages) > 0 {
+		msgs, err = getPages(s, thread, b.pageIDs())
+	} else {
+		msgs, err = getLeaderboardMessages(s, thread)
+	}
+	if err != nil {
+		return ld, err
+	}
+	for _, msg := range msgs {
+		ld.msgs = append(ld.msgs, newDiscordMessage(msg))
+	}
+
+	switch {
+	case !ok:
+		b = parseBoard(msgs)
+	case b.renderedElsewhere(ld.msgs):
+		log.Printf("leaderboard %v was rendered by another instance, importing it again", thread.ID)
+		b = b.reimport(msgs)
+	}
+	ld.board = b
+	if !ok || !samePages(pagesOf(ld.msgs), b.Pages) {
+		if err := ld.save(); err != nil {
+			return ld, err
+		}
+	}
+	return ld, nil
+}
+
+// loadBoard returns the leaderboard of a thread, without the messages it is rendered into.
+// It writes nothing: leaderboards not stored yet, such as those of seasons closed before the store, are parsed every time.
+func loadBoard(s *discordgo.Session, thread *discordgo.Channel) (board, error) {
+	b, ok, err := Boards.Load(thread.GuildID, thread.ID)
+	if err != nil || ok {
+		return b, err
+	}
+	msgs, err := getLeaderboardMessages(s, thread)
+	if err != nil {
+		return b, err
+	}
+	return parseBoard(msgs), nil
+}
+
+// save stores the leaderboard, along with the pages as they are now.
+func (ld *leaderboardData) save() error {
+	ld.Pages = pagesOf(ld.msgs)
+	return Boards.Save(ld.guildID, ld.channelID, &ld.board)
+}
 
+// how many messages are fetched at once when looking for the pages of a leaderboard
+const messagesBatchSize = 100
+
+// getTopMessages returns the messages of the bot at the top of the thread, oldest first,
+// which are the pages of the leaderboard unless it outgrew them.
+func getTopMessages(s *discordgo.Session, thread *discordgo.Channel) ([]*discordgo.Message, error) {
 	// ids are basically timestamps, and 'after' is strict, so decrement initial message by one...
 	tID, err := strconv.ParseUint(thread.ID, 10, 64)
 	if err != nil {
-		return ld, err
+		return nil, err
 	}
 	afterID := strconv.FormatUint(tID-1, 10)
 
 	// placeholders + top message + instructions. could fetch more to be sure, since we filter out other messages for safety below.
 	msgs, err := s.ChannelMessages(thread.ID, numPlaceholderMessages+2, "", afterID, "")
 	if err != nil {
-		return ld, err
+		return nil, err
 	}
 	if len(msgs) == 0 {
-		return ld, errors.New("no messages in channel")
+		return nil, errors.New("no messages in channel")
 	}
 	msgs = slices.DeleteFunc(msgs, func(msg *discordgo.Message) bool {
 		return msg.Type != discordgo.MessageTypeDefault || msg.Author.ID != thread.OwnerID
 	})
 	// we get them in anti-chronological order
 	slices.Reverse(msgs)
+	return msgs, nil
+}
+
+// getLeaderboardMessages finds the pages of a leaderboard that does not know them yet, oldest first:
+// the messages of the bot from the one starting with the leaderboard header to the one with the instructions,
+// wherever in the thread they were posted, followed by the placeholders reserved right after.
+// Other messages of the bot, such as pings, are told apart by their content.
+func getLeaderboardMessages(s *discordgo.Session, thread *discordgo.Channel) ([]*discordgo.Message, error) {
+	tID, err := strconv.ParseUint(thread.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	afterID := strconv.FormatUint(tID-1, 10)
+
+	var pages []*discordgo.Message
+	started, ended := false, false
+	for {
+		msgs, err := s.ChannelMessages(thread.ID, messagesBatchSize, "", afterID, "")
+		if err != nil {
+			return nil, err
+		}
+		// we get them in anti-chronological order
+		slices.Reverse(msgs)
+		for _, msg := range msgs {
+			page := msg.Type == discordgo.MessageTypeDefault && msg.Author.ID == thread.OwnerID && isPage(msg.Content)
+			switch {
+			case ended && (!page || msg.Content != placeholderMessage):
+				return pages, nil
+			case !page || (!started && !strings.HasPrefix(msg.Content, leaderboardMessagePrefix)):
+				continue
+			}
+			started = true
+			pages = append(pages, msg)
+			ended = strings.HasPrefix(msg.Content, instructionsMessagePrefix)
+		}
+		if len(msgs) < messagesBatchSize {
+			break
+		}
+		afterID = msgs[len(msgs)-1].ID
+	}
+	if len(pages) == 0 {
+		return nil, errors.New("no leaderboard in channel")
+	}
+	return pages, nil
+}
 
-	inUnorderedSection := false
+// isPage tells the pages of a leaderboard apart from the other messages of the bot, from their first line.
+func isPage(content string) bool {
+	first, _, _ := strings.Cut(content, "\n")
+	switch first {
+	case placeholderMessage, leaderboardMessagePrefix, staleMessagePrefix, unknownRankMessagePrefix, conflictsMessagePrefix, instructionsMessagePrefix:
+		return true
+	}
+	if strings.HasPrefix(first, unorderedPrefix) {
+		return true
+	}
+	rank, _, ok := strings.Cut(first, "\\. ")
+	_, err := strconv.Atoi(rank)
+	return ok && err == nil
+}
+
+// parseBoard imports a leaderboard from the messages it was rendered into.
+func parseBoard(msgs []*discordgo.Message) board {
+	var b board
+	inUnorderedSection, inConflictsSection := false, false
 	for _, msg := range msgs {
-		ld.msgs = append(ld.msgs, discordMessage{id: msg.ID, content: msg.Content})
 	linesLoop:
 		for _, l := range strings.Split(msg.Content, "\n") {
 			switch l {
 			case "", placeholderMessage, leaderboardMessagePrefix:
 				continue
+			case staleMessagePrefix:
+				// ranked too, just listed apart
+				continue
 			case unknownRankMessagePrefix:
 				inUnorderedSection = true
 				continue
+			case conflictsMessagePrefix:
+				// only derived from the entries, and may go on over several pages
+				inConflictsSection = true
+				continue
 			case instructionsMessagePrefix:
 				break linesLoop
 			}
 
-			if inUnorderedSection {
-				ld.unordered = append(ld.unordered, l)
-			} else {
-				ld.ranked = append(ld.ranked, l)
+			switch {
+			case inConflictsSection:
+			case inUnorderedSection:
+				b.Unordered = append(b.Unordered, parseEntry(strings.TrimPrefix(l, unorderedPrefix)))
+			default:
+				b.Ranked = append(b.Ranked, parseEntry(l))
 			}
 		}
 	}
-
-	return ld, nil
+	// stale ranks may have been listed after the others
+	slices.SortStableFunc(b.Ranked, func(x, y entry) int { return cmp.Compare(x.rank, y.rank) })
+	return b
 }
 
-func (ld leaderboardData) updateMessages(s *discordgo.Session) error {
+// updateMessages renders the leaderboard into its pages, posting new ones at the end of the thread if it outgrew them.
+func (ld *leaderboardData) updateMessages(s *discordgo.Session) error {
 	currentMessageIndex := 0
 	currentPageContent := leaderboardMessagePrefix
 
 	postPage := func() error {
 		if currentMessageIndex >= len(ld.msgs) {
-			return fmt.Errorf("ran out of pages (%d available)", len(ld.msgs))
+			if err := ld.addPage(s, currentPageContent); err != nil {
+				return fmt.Errorf("ran out of pages (%d available) and failed to add one: %w", len(ld.msgs), err)
+			}
+			currentMessageIndex++
+			currentPageContent = ""
+			return nil
 		}
-		msg := ld.msgs[currentMessageIndex]
+		msg := &ld.msgs[currentMessageIndex]
 		if currentPageContent != msg.content {
-			if _, err := s.ChannelMessageEdit(ld.channelID, msg.id, currentPageContent); err != nil {
+			if err := ld.checkPageUnchanged(s, *msg); err != nil {
+				return err
+			}
+			edited, err := s.ChannelMessageEdit(ld.channelID, msg.id, currentPageContent)
+			if err != nil {
 				return err
 			}
+			*msg = newDiscordMessage(edited)
 		}
 		currentMessageIndex++
 		currentPageContent = ""
@@ -157,21 +352,57 @@ func (ld leaderboardData) updateMessages(s *discordgo.Session) error {
 		return nil
 	}
 
-	if err := printLines(ld.ranked); err != nil {
+	now := time.Now()
+	var ranked, stale []string
+	for _, e := range ld.Ranked {
+		l := e.format(ld.printer)
+		switch {
+		case !ld.settings.stale(e, now):
+			ranked = append(ranked, l)
+		case ld.settings.SeparateStale:
+			stale = append(stale, l)
+		default:
+			ranked = append(ranked, l+" "+staleMarker)
+		}
+	}
+	if err := printLines(ranked); err != nil {
 		return err
 	}
 
-	if len(ld.unordered) > 0 {
+	if len(stale) > 0 {
+		currentPageContent = staleMessagePrefix
+		if err := printLines(stale); err != nil {
+			return err
+		}
+	}
+
+	if len(ld.Unordered) > 0 {
 		currentPageContent = unknownRankMessagePrefix
 
+		var unordered []string
+		for _, e := range ld.Unordered {
+			unordered = append(unordered, unorderedPrefix+e.format(ld.printer))
+		}
 		// ironic... :P
-		slices.Sort(ld.unordered)
-		if err := printLines(ld.unordered); err != nil {
+		slices.Sort(unordered)
+		if err := printLines(unordered); err != nil {
 			return err
 		}
 	}
 
-	currentPageContent = instructionsMessage(ld.appID)
+	if conflicts := ld.conflicts(); len(conflicts) > 0 {
+		currentPageContent = conflictsMessagePrefix
+
+		var lines []string
+		for _, c := range conflicts {
+			lines = append(lines, unorderedPrefix+c.format(ld.printer, entry.player))
+		}
+		if err := printLines(lines); err != nil {
+			return err
+		}
+	}
+
+	currentPageContent = instructionsMessage(ld.printer, ld.appID)
 	if err := postPage(); err != nil {
 		return err
 	}
@@ -191,58 +422,48 @@ func (ld *leaderboardData) addEntry(ent entry) error {
 
 	var (
 		added  bool
-		update []string
+		update []entry
 	)
-	for _, e := range ld.ranked {
-		r, n, isID := getRankAndName(e)
-		if (isID && n == ent.userID) || n == ent.name {
+	for _, e := range ld.Ranked {
+		if e.samePlayer(ent) {
 			if rankUnknown {
 				return errors.New("player already ranked")
 			}
 			continue
 		}
-		if !rankUnknown && !added && (r >= ent.rank) {
-			update = append(update, ent.String())
+		if !rankUnknown && !added && (e.rank >= ent.rank) {
+			update = append(update, ent)
 			added = true
 		}
 		update = append(update, e)
 	}
 	if !rankUnknown && !added {
-		update = append(update, ent.String())
+		update = append(update, ent)
 		added = true
 	}
-	ld.ranked = update
+	ld.Ranked = update
 
-	ent.rank = 0
-	newEntry := unorderedPrefix + ent.String()
 	if added {
-		ld.unordered = slices.DeleteFunc(ld.unordered, func(e string) bool { return e == newEntry })
+		ld.Unordered = slices.DeleteFunc(ld.Unordered, ent.samePlayer)
 	} else {
-		if slices.Contains(ld.unordered, newEntry) {
+		if slices.ContainsFunc(ld.Unordered, ent.samePlayer) {
 			return errors.New("player already reported as master")
 		}
-		ld.unordered = append(ld.unordered, newEntry)
+		ld.Unordered = append(ld.Unordered, ent)
 	}
+	ld.History = append(ld.History, ent)
 	return nil
 }
 
+// removeEntries deletes the entries of the players matching, along with their reports in the history,
+// as those an admin deletes are not meant to show up anywhere anymore. With a rank, only entries and reports of that rank are.
 func (ld *leaderboardData) removeEntries(nameOrMention string, rank int) {
-	if rank == 0 {
-		ld.ranked = slices.DeleteFunc(ld.ranked, func(e string) bool {
-			_, after, _ := strings.Cut(e, "\\. ")
-			return strings.HasPrefix(after, nameOrMention)
-		})
-
-		prefix := unorderedPrefix + nameOrMention
-		ld.unordered = slices.DeleteFunc(ld.unordered, func(e string) bool {
-			return strings.HasPrefix(e, prefix)
-		})
+	matches := func(e entry) bool {
+		return (rank == 0 || e.rank == rank) && strings.HasPrefix(e.player(), nameOrMention)
 	}
-
-	if rank > 0 {
-		prefix := fmt.Sprintf("%d\\. %s", rank, nameOrMention)
-		ld.ranked = slices.DeleteFunc(ld.ranked, func(e string) bool {
-			return strings.HasPrefix(e, prefix)
-		})
+	ld.Ranked = slices.DeleteFunc(ld.Ranked, matches)
+	if rank == 0 {
+		ld.Unordered = slices.DeleteFunc(ld.Unordered, matches)
 	}
+	ld.History = slices.DeleteFunc(ld.History, matches)
 }
@@ -0,0 +1,110 @@
+package leaderboard
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func players(entries []entry) []string {
+	var res []string
+	for _, e := range entries {
+		res = append(res, e.player())
+	}
+	return res
+}
+
+func TestAddEntry(t *testing.T) {
+	var ld leaderboardData
+	for _, e := range []entry{
+		{userID: "111", rank: 2, timestamp: 1},
+		{userID: "222", rank: 1, timestamp: 2},
+		{userID: "333", timestamp: 3},
+		{userID: "333", rank: 3, timestamp: 4},
+	} {
+		if err := ld.addEntry(e); err != nil {
+			t.Fatalf("addEntry(%v): %v", e, err)
+		}
+	}
+	if got := players(ld.Ranked); len(got) != 3 || got[0] != "<@222>" || got[1] != "<@111>" || got[2] != "<@333>" {
+		t.Errorf("ranked = %v", got)
+	}
+	if len(ld.Unordered) != 0 {
+		t.Errorf("unordered = %v, want the ranked player removed", ld.Unordered)
+	}
+	if len(ld.History) != 4 {
+		t.Errorf("history = %v, want every report", ld.History)
+	}
+
+	if err := ld.addEntry(entry{userID: "111", timestamp: 5}); err == nil {
+		t.Error("adding a ranked player without rank should fail")
+	}
+	if err := ld.addEntry(entry{name: "Name", timestamp: 6}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ld.addEntry(entry{name: "Name", timestamp: 7}); err == nil {
+		t.Error("adding an other master twice should fail")
+	}
+}
+
+func TestRemoveEntries(t *testing.T) {
+	newData := func() leaderboardData {
+		var ld leaderboardData
+		ld.Ranked = []entry{{userID: "111", rank: 1}, {userID: "222", rank: 2}}
+		ld.Unordered = []entry{{name: "Name"}}
+		ld.History = []entry{{userID: "111", rank: 3}, {userID: "111", rank: 1}, {userID: "222", rank: 2}, {name: "Name"}}
+		return ld
+	}
+
+	ld := newData()
+	ld.removeEntries("<@111>", 0)
+	if got := players(ld.Ranked); len(got) != 1 || got[0] != "<@222>" {
+		t.Errorf("ranked = %v", got)
+	}
+	if got := players(ld.History); len(got) != 2 || got[0] != "<@222>" {
+		t.Errorf("history = %v, want the reports of the player removed", got)
+	}
+
+	ld = newData()
+	ld.removeEntries("<@111>", 3)
+	if len(ld.Ranked) != 2 || len(ld.History) != 3 {
+		t.Errorf("ranked = %v, history = %v, want only the report of rank 3 removed", ld.Ranked, ld.History)
+	}
+
+	ld = newData()
+	ld.removeEntries("Na", 0)
+	if len(ld.Unordered) != 0 || len(ld.History) != 3 {
+		t.Errorf("unordered = %v, history = %v, want the other master removed", ld.Unordered, ld.History)
+	}
+}
+
+func TestParseBoard(t *testing.T) {
+	msgs := []*discordgo.Message{
+		{Content: leaderboardMessagePrefix + "\n2\\. <@222> (?) — <t:900:R>\n3\\. <@333> (?) — <t:100:R> " + staleMarker},
+		{Content: staleMessagePrefix + "\n1\\. <@111> (?) — <t:100:R> (<@999>)"},
+		{Content: "4\\. Name (~5k)"},
+		{Content: unknownRankMessagePrefix + "\n- <@777>"},
+		{Content: conflictsMessagePrefix + "\n- <@111> is ranked"},
+		{Content: "- more conflicts"},
+		{Content: instructionsMessagePrefix + "\nAdd master rank players"},
+		{Content: placeholderMessage},
+	}
+	b := parseBoard(msgs)
+	want := []entry{
+		{userID: "111", rank: 1, timestamp: 100, reporterID: "999"},
+		{userID: "222", rank: 2, timestamp: 900},
+		{userID: "333", rank: 3, timestamp: 100},
+		{name: "Name", rank: 4, points: 5000, approx: true},
+	}
+	if len(b.Ranked) != len(want) {
+		t.Fatalf("ranked = %v, want %v", b.Ranked, want)
+	}
+	for k := range want {
+		if b.Ranked[k] != want[k] {
+			t.Errorf("ranked[%d] = %+v, want %+v", k, b.Ranked[k], want[k])
+		}
+	}
+	if len(b.Unordered) != 1 || b.Unordered[0].userID != "777" {
+		t.Errorf("unordered = %+v", b.Unordered)
+	}
+}
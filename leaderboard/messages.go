@@ -0,0 +1,185 @@
+package leaderboard
+
+import (
+	"fmt"
+
+	"github.com/itizir/hrv/i18n"
+	"golang.org/x/text/language"
+)
+
+func init() {
+	i18n.RegisterNames(language.French, map[string]string{
+		"rank":       "classement",
+		"rank_admin": "classement-admin",
+		"delete":     "supprimer",
+		"start":      "commencer",
+		"name":       "nom",
+		"season":     "saison",
+		"rebuild":    "reconstruire",
+		"compact":    "compacter",
+		"report":     "signaler",
+		"history":    "historique",
+		"player":     "joueur",
+		"show":       "afficher",
+		"resolve":    "résoudre",
+		"conflict":   "conflit",
+		"action":     "action",
+		"staleness":  "péremption",
+		"days":       "jours",
+		"separate":   "séparer",
+	})
+	i18n.RegisterNames(language.German, map[string]string{
+		"rank":       "rang",
+		"rank_admin": "rang-admin",
+		"delete":     "löschen",
+		"start":      "starten",
+		"name":       "name",
+		"season":     "saison",
+		"rebuild":    "neu-aufbauen",
+		"compact":    "verdichten",
+		"report":     "melden",
+		"history":    "verlauf",
+		"player":     "spieler",
+		"show":       "anzeigen",
+		"resolve":    "auflösen",
+		"conflict":   "konflikt",
+		"action":     "aktion",
+		"staleness":  "veraltung",
+		"days":       "tage",
+		"separate":   "getrennt",
+	})
+
+	i18n.Register(language.French, map[string]string{
+		"Report player rank for leaderboard":                                 "Signaler le rang d'un joueur pour le classement",
+		"Admin functions for leaderboard":                                    "Fonctions d'administration du classement",
+		"Remove a player rank entry from leaderboard":                        "Retirer l'entrée d'un joueur du classement",
+		"Player name (prefix-matching)":                                      "Nom du joueur (début du nom)",
+		"Player rank":                                                        "Rang du joueur",
+		"Season number, defaults to latest":                                  "Numéro de saison, la plus récente par défaut",
+		"Start a new season":                                                 "Commencer une nouvelle saison",
+		fmt.Sprintf("Season name, needs to start with %q", threadNamePrefix): fmt.Sprintf("Nom de la saison, doit commencer par %q", threadNamePrefix),
+
+		"Update Player Rank": "Mettre à jour le rang",
+		"Rank":               "Rang",
+		"Leaderboard position held. Leave empty if unknown": "Position au classement. Laisser vide si inconnue",
+		"Rank points": "Points de classement",
+		"Optional. Prefix ~ if approx, suffix ? if guess": "Facultatif. Préfixe ~ si approx., suffixe ? si estimé",
+		"Player":                                 "Joueur",
+		"Leave empty if reporting your own rank": "Laisser vide pour votre propre rang",
+		"Season":                                 "Saison",
+		"Season number":                          "Numéro de saison",
+
+		"Failed to edit leaderboard: %v.":     "Échec de la modification du classement : %v.",
+		"Leaderboard %s successfully edited.": "Classement %s modifié avec succès.",
+		"Not yet implemented!":                "Pas encore implémenté !",
+		"OK!":                                 "OK !",
+
+		instructionsMessageBody: `Ajoutez les joueurs de rang maître au classement avec la commande ` + "`rank`" + ` de %[1]s (qu'il suffit de taper ` + "`/rank report`" + ` n'importe où sur ce serveur).
+Attention à ne pas confondre la commande ` + "`/rank`" + ` d'autres bots avec celle de %[1]s !
+
+Vous pouvez indiquer en option les points de classement exacts (p. ex. ` + "`35123`" + `), approximatifs (p. ex. ` + "`~77000`" + `) ou estimés (p. ex. ` + "`180000?`" + `).
+
+Pour signaler le rang d'un autre membre Discord, inutile d'entrer son nom complet tant qu'il l'identifie sans ambiguïté ; la priorité est donnée à une correspondance exacte du _nom d'utilisateur_.`,
+		"Render the stored leaderboard into its thread again":      "Réafficher le classement enregistré dans son fil",
+		"Also delete the pages left unused at the end":             "Supprimer aussi les pages inutilisées à la fin",
+		"Master rank leaderboard":                                  "Classement des joueurs de rang maître",
+		"Show the ranks reported for a player over the seasons":    "Afficher les rangs signalés pour un joueur au fil des saisons",
+		"Player, defaults to yourself":                             "Joueur, vous-même par défaut",
+		"Rank history of %s:":                                      "Historique des rangs de %s :",
+		"No rank reported for %s yet.":                             "Aucun rang signalé pour %s pour l'instant.",
+		"other master":                                             "autre maître",
+		"Show where a player stands, now and in past seasons":      "Afficher la position d'un joueur, actuelle et lors des saisons passées",
+		"No leaderboards yet.":                                     "Aucun classement pour l'instant.",
+		"Profile of %s:":                                           "Profil de %s :",
+		"**%s**: #%d %s":                                           "**%s** : n° %d %s",
+		"Reported %s by %s.":                                       "Signalé %s par %s.",
+		"Reported %s.":                                             "Signalé %s.",
+		"**%s**: among the other masters (%d of %d)":               "**%s** : parmi les autres maîtres (%d sur %d)",
+		"**%s**: not on the leaderboard":                           "**%s** : absent du classement",
+		"Best ranks in past seasons:":                              "Meilleurs rangs des saisons passées :",
+		"Queued! You will be told here once %s is updated.":        "En file d'attente ! Vous serez prévenu ici une fois %s mis à jour.",
+		"Too many updates waiting for %s, sorry; try again later.": "Trop de mises à jour en attente pour %s, désolé ; réessayez plus tard.",
+		"Need at least a name or a rank.":                          "Il faut au moins un nom ou un rang.",
+		"Resolve a conflict listed as needing attention":           "Résoudre un conflit signalé comme à vérifier",
+		"Conflict to resolve":                                      "Conflit à résoudre",
+		"keep_newest unranks older claims, shift moves them down, dismiss leaves things be": "keep_newest retire le rang des plus anciens, shift les décale vers le bas, dismiss ne change rien",
+		"%s all claim rank #%d":                                                                                              "%s revendiquent tous le rang n° %d",
+		"%s is ranked #%d with fewer points than %s, ranked #%d":                                                             "%s est n° %d avec moins de points que %s, n° %d",
+		"Show or set when reported ranks go stale":                                                                           "Afficher ou définir quand les rangs signalés deviennent périmés",
+		"Days until a rank goes stale, noticed on updates (or hourly in websocket mode); 0 for never":                        "Jours avant qu'un rang soit périmé, vu aux mises à jour (ou chaque heure en websocket) ; 0 : jamais",
+		"List stale ranks in their own section instead of just marking them":                                                 "Lister les rangs périmés dans leur propre section au lieu de les marquer",
+		"Reported ranks never go stale.":                                                                                     "Les rangs signalés ne deviennent jamais périmés.",
+		"Ranks reported more than %d days ago are listed in their own section, and the players asked to confirm them.":       "Les rangs signalés il y a plus de %d jours sont listés dans leur propre section, et les joueurs invités à les confirmer.",
+		"Ranks reported more than %d days ago are marked with %s, and the players asked to confirm them.":                    "Les rangs signalés il y a plus de %d jours sont marqués de %s, et les joueurs invités à les confirmer.",
+		"Your rank on this leaderboard was reported more than %d days ago: please confirm or update it with `/rank report`!": "Votre rang dans ce classement a été signalé il y a plus de %d jours : merci de le confirmer ou de le mettre à jour avec `/rank report` !",
+		"Ranks going stale are looked for every hour.":                                                                       "Les rangs qui deviennent périmés sont recherchés toutes les heures.",
+		"Ranks going stale are only noticed when the leaderboard is updated.":                                                "Les rangs qui deviennent périmés ne sont remarqués qu'à la mise à jour du classement.",
+	})
+
+	i18n.Register(language.German, map[string]string{
+		"Report player rank for leaderboard":                                 "Spielerrang für die Bestenliste melden",
+		"Admin functions for leaderboard":                                    "Verwaltungsfunktionen der Bestenliste",
+		"Remove a player rank entry from leaderboard":                        "Eintrag eines Spielers aus der Bestenliste entfernen",
+		"Player name (prefix-matching)":                                      "Spielername (Anfang des Namens)",
+		"Player rank":                                                        "Spielerrang",
+		"Season number, defaults to latest":                                  "Saisonnummer, standardmäßig die neueste",
+		"Start a new season":                                                 "Neue Saison starten",
+		fmt.Sprintf("Season name, needs to start with %q", threadNamePrefix): fmt.Sprintf("Name der Saison, muss mit %q beginnen", threadNamePrefix),
+
+		"Update Player Rank": "Spielerrang aktualisieren",
+		"Rank":               "Rang",
+		"Leaderboard position held. Leave empty if unknown": "Position in der Bestenliste. Leer lassen, falls unbekannt",
+		"Rank points": "Ranglistenpunkte",
+		"Optional. Prefix ~ if approx, suffix ? if guess": "Optional. Präfix ~ wenn ungefähr, Suffix ? wenn geschätzt",
+		"Player":                                 "Spieler",
+		"Leave empty if reporting your own rank": "Leer lassen für den eigenen Rang",
+		"Season":                                 "Saison",
+		"Season number":                          "Saisonnummer",
+
+		"Failed to edit leaderboard: %v.":     "Bestenliste konnte nicht bearbeitet werden: %v.",
+		"Leaderboard %s successfully edited.": "Bestenliste %s erfolgreich bearbeitet.",
+		"Not yet implemented!":                "Noch nicht implementiert!",
+		"OK!":                                 "OK!",
+
+		instructionsMessageBody: `Füge Spieler mit Meisterrang zur Bestenliste hinzu, indem du den ` + "`rank`" + `-Befehl von %[1]s aufrufst (einfach ` + "`/rank report`" + ` irgendwo auf diesem Server eintippen).
+Verwechsle dabei nicht den ` + "`/rank`" + `-Befehl anderer Bots mit dem von %[1]s!
+
+Optional kannst du genaue (z. B. ` + "`35123`" + `), ungefähre (z. B. ` + "`~77000`" + `) oder geschätzte (z. B. ` + "`180000?`" + `) Ranglistenpunkte angeben.
+
+Wenn du für ein anderes Discord-Mitglied meldest, musst du nicht den ganzen Namen eingeben, solange er eindeutig ist; ein exakter Treffer beim _Benutzernamen_ hat Vorrang.`,
+		"Render the stored leaderboard into its thread again":      "Die gespeicherte Bestenliste erneut in ihrem Thread darstellen",
+		"Also delete the pages left unused at the end":             "Auch die am Ende ungenutzten Seiten löschen",
+		"Master rank leaderboard":                                  "Bestenliste der Spieler mit Meisterrang",
+		"Show the ranks reported for a player over the seasons":    "Die gemeldeten Ränge eines Spielers über die Saisons anzeigen",
+		"Player, defaults to yourself":                             "Spieler, standardmäßig du selbst",
+		"Rank history of %s:":                                      "Rangverlauf von %s:",
+		"No rank reported for %s yet.":                             "Für %s wurde noch kein Rang gemeldet.",
+		"other master":                                             "weiterer Meister",
+		"Show where a player stands, now and in past seasons":      "Anzeigen, wo ein Spieler steht, jetzt und in vergangenen Saisons",
+		"No leaderboards yet.":                                     "Noch keine Bestenlisten.",
+		"Profile of %s:":                                           "Profil von %s:",
+		"**%s**: #%d %s":                                           "**%s**: Platz %d %s",
+		"Reported %s by %s.":                                       "Gemeldet %s von %s.",
+		"Reported %s.":                                             "Gemeldet %s.",
+		"**%s**: among the other masters (%d of %d)":               "**%s**: unter den weiteren Meistern (%d von %d)",
+		"**%s**: not on the leaderboard":                           "**%s**: nicht in der Bestenliste",
+		"Best ranks in past seasons:":                              "Beste Ränge in vergangenen Saisons:",
+		"Queued! You will be told here once %s is updated.":        "In der Warteschlange! Du erfährst hier, sobald %s aktualisiert ist.",
+		"Too many updates waiting for %s, sorry; try again later.": "Zu viele Aktualisierungen für %s in der Warteschlange, sorry; versuch es später noch einmal.",
+		"Need at least a name or a rank.":                          "Mindestens ein Name oder ein Rang wird benötigt.",
+		"Resolve a conflict listed as needing attention":           "Einen als prüfungsbedürftig gelisteten Konflikt auflösen",
+		"Conflict to resolve":                                      "Aufzulösender Konflikt",
+		"keep_newest unranks older claims, shift moves them down, dismiss leaves things be": "keep_newest entfernt ältere Ränge, shift verschiebt sie nach unten, dismiss lässt alles, wie es ist",
+		"%s all claim rank #%d":                                                                                              "%s beanspruchen alle Platz %d",
+		"%s is ranked #%d with fewer points than %s, ranked #%d":                                                             "%s ist auf Platz %d mit weniger Punkten als %s auf Platz %d",
+		"Show or set when reported ranks go stale":                                                                           "Anzeigen oder festlegen, wann gemeldete Ränge veralten",
+		"Days until a rank goes stale, noticed on updates (or hourly in websocket mode); 0 for never":                        "Tage, bis ein Rang veraltet, erkannt bei Updates (oder stündlich im Websocket-Modus); 0 für nie",
+		"List stale ranks in their own section instead of just marking them":                                                 "Veraltete Ränge in einem eigenen Abschnitt auflisten, statt sie nur zu markieren",
+		"Reported ranks never go stale.":                                                                                     "Gemeldete Ränge veralten nie.",
+		"Ranks reported more than %d days ago are listed in their own section, and the players asked to confirm them.":       "Vor mehr als %d Tagen gemeldete Ränge werden in einem eigenen Abschnitt aufgelistet, und die Spieler werden gebeten, sie zu bestätigen.",
+		"Ranks reported more than %d days ago are marked with %s, and the players asked to confirm them.":                    "Vor mehr als %d Tagen gemeldete Ränge werden mit %s markiert, und die Spieler werden gebeten, sie zu bestätigen.",
+		"Your rank on this leaderboard was reported more than %d days ago: please confirm or update it with `/rank report`!": "Dein Rang in dieser Bestenliste wurde vor mehr als %d Tagen gemeldet: bitte bestätige oder aktualisiere ihn mit `/rank report`!",
+		"Ranks going stale are looked for every hour.":                                                                       "Veraltende Ränge werden stündlich gesucht.",
+		"Ranks going stale are only noticed when the leaderboard is updated.":                                                "Veraltende Ränge werden nur bemerkt, wenn die Bestenliste aktualisiert wird.",
+	})
+}
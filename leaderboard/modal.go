@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
 )
 
 const (
@@ -25,42 +26,43 @@ func presentModal(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 		return err
 	}
 
+	p := i18n.Printer(i.Interaction)
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
-			Title:    "Update Player Rank",
+			Title:    p.Sprintf("Update Player Rank"),
 			CustomID: ApplicationCommand.Name,
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{Components: []discordgo.MessageComponent{
 					discordgo.TextInput{
-						Label:       "Rank",
+						Label:       p.Sprintf("Rank"),
 						Style:       discordgo.TextInputShort,
-						Placeholder: "Leaderboard position held. Leave empty if unknown",
+						Placeholder: p.Sprintf("Leaderboard position held. Leave empty if unknown"),
 						CustomID:    modalKeyRank,
 						MaxLength:   6,
 					},
 				}},
 				discordgo.ActionsRow{Components: []discordgo.MessageComponent{
 					discordgo.TextInput{
-						Label:       "Rank points",
+						Label:       p.Sprintf("Rank points"),
 						Style:       discordgo.TextInputShort,
-						Placeholder: "Optional. Prefix ~ if approx, suffix ? if guess",
+						Placeholder: p.Sprintf("Optional. Prefix ~ if approx, suffix ? if guess"),
 						CustomID:    modalKeyRankPoints,
 						MaxLength:   10,
 					},
 				}},
 				discordgo.ActionsRow{Components: []discordgo.MessageComponent{
-					discordgo.TextInput{Label: "Player",
+					discordgo.TextInput{Label: p.Sprintf("Player"),
 						Style:       discordgo.TextInputShort,
-						Placeholder: "Leave empty if reporting your own rank",
+						Placeholder: p.Sprintf("Leave empty if reporting your own rank"),
 						CustomID:    modalKeyPlayer,
 						MaxLength:   80,
 					},
 				}},
 				discordgo.ActionsRow{Components: []discordgo.MessageComponent{
-					discordgo.TextInput{Label: "Season",
+					discordgo.TextInput{Label: p.Sprintf("Season"),
 						Style:       discordgo.TextInputShort,
-						Placeholder: "Season number",
+						Placeholder: p.Sprintf("Season number"),
 						Value:       strconv.Itoa(currentSeason),
 						CustomID:    modalKeySeason,
 						MaxLength:   2,
@@ -0,0 +1,187 @@
+package leaderboard
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// getPages fetches the pages of a leaderboard. Those near the top of the thread come in one go,
+// but the ones added later, possibly after a lot of chatter, are fetched one by one.
+// Pages deleted by someone are left out, to be replaced by new ones as needed.
+func getPages(s *discordgo.Session, thread *discordgo.Channel, pages []string) ([]*discordgo.Message, error) {
+	top, err := getTopMessages(s, thread)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*discordgo.Message, len(top))
+	for _, msg := range top {
+		byID[msg.ID] = msg
+	}
+
+	msgs := make([]*discordgo.Message, 0, len(pages))
+	for _, id := range pages {
+		msg, ok := byID[id]
+		if !ok {
+			msg, err = s.ChannelMessage(thread.ID, id)
+			var restErr *discordgo.RESTError
+			if errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("page %v of leaderboard %v is gone", id, thread.ID)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch page %v: %w", id, err)
+			}
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+func (b board) pageIDs() []string {
+	var ids []string
+	for _, pg := range b.Pages {
+		ids = append(ids, pg.ID)
+	}
+	return ids
+}
+
+func pagesOf(msgs []discordMessage) []page {
+	var pages []page
+	for _, m := range msgs {
+		pages = append(pages, page{ID: m.id, Edited: m.edited})
+	}
+	return pages
+}
+
+func samePages(a, b []page) bool {
+	return slices.EqualFunc(a, b, func(x, y page) bool { return x.ID == y.ID && x.Edited.Equal(y.Edited) })
+}
+
+// renderedElsewhere tells whether any of the messages was edited since this leaderboard was last rendered into it,
+// which only another instance of the bot, with a leaderboard of its own, does.
+func (b board) renderedElsewhere(msgs []discordMessage) bool {
+	for _, m := range msgs {
+		k := slices.IndexFunc(b.Pages, func(pg page) bool { return pg.ID == m.id })
+		if k >= 0 && !b.Pages[k].Edited.Equal(m.edited) {
+			return true
+		}
+	}
+	return false
+}
+
+// reimport replaces the entries with those rendered into the messages by another instance of the bot.
+// What only the store knows of is kept as far as it still applies: reports are added to the history,
+// and players keep being known as pinged about the ranks they were pinged about.
+func (b board) reimport(msgs []*discordgo.Message) board {
+	parsed := parseBoard(msgs)
+	for k, e := range parsed.Ranked {
+		parsed.Ranked[k].notified = slices.ContainsFunc(b.Ranked, func(o entry) bool {
+			return o.notified && o.sameReport(e)
+		})
+	}
+	for _, e := range slices.Concat(parsed.Ranked, parsed.Unordered) {
+		if e.timestamp > 0 && !slices.ContainsFunc(b.History, e.sameReport) {
+			b.History = append(b.History, e)
+		}
+	}
+	slices.SortStableFunc(b.History, func(x, y entry) int { return cmp.Compare(x.timestamp, y.timestamp) })
+
+	b.Ranked, b.Unordered = parsed.Ranked, parsed.Unordered
+	return b
+}
+
+func newDiscordMessage(msg *discordgo.Message) discordMessage {
+	m := discordMessage{id: msg.ID, content: msg.Content}
+	if msg.EditedTimestamp != nil {
+		m.edited = *msg.EditedTimestamp
+	}
+	return m
+}
+
+func (m discordMessage) same(o discordMessage) bool {
+	return m.id == o.id && m.content == o.content && m.edited.Equal(o.edited)
+}
+
+// reloadPages fetches the pages again, as they are now, to render the leaderboard into them once more.
+func (ld *leaderboardData) reloadPages(s *discordgo.Session) error {
+	thread := &discordgo.Channel{ID: ld.channelID, OwnerID: ld.appID}
+	msgs, err := getPages(s, thread, ld.pageIDs())
+	if err != nil {
+		return err
+	}
+	ld.msgs = nil
+	for _, msg := range msgs {
+		ld.msgs = append(ld.msgs, newDiscordMessage(msg))
+	}
+	return nil
+}
+
+// checkUnchanged fetches the pages again, and fails with errConflict if any was edited since the leaderboard was loaded:
+// another instance of the bot got to update it in the meantime.
+func (ld *leaderboardData) checkUnchanged(s *discordgo.Session) error {
+	thread := &discordgo.Channel{ID: ld.channelID, OwnerID: ld.appID}
+	var ids []string
+	for _, m := range ld.msgs {
+		ids = append(ids, m.id)
+	}
+	msgs, err := getPages(s, thread, ids)
+	if err != nil {
+		return err
+	}
+	if len(msgs) != len(ld.msgs) {
+		return errConflict
+	}
+	for k, msg := range msgs {
+		if !newDiscordMessage(msg).same(ld.msgs[k]) {
+			return errConflict
+		}
+	}
+	return nil
+}
+
+// checkPageUnchanged fetches a page again right before it is edited, and fails with errConflict
+// if another instance of the bot edited it since the leaderboard was loaded. Discord offers no conditional edit,
+// so another instance may still edit it in between: only instances sharing their store, on a single host, are sure
+// to keep the leaderboard itself consistent, while others may lose an update in that short window.
+func (ld *leaderboardData) checkPageUnchanged(s *discordgo.Session, m discordMessage) error {
+	msg, err := s.ChannelMessage(ld.channelID, m.id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch page %v: %w", m.id, err)
+	}
+	if !newDiscordMessage(msg).same(m) {
+		return errConflict
+	}
+	return nil
+}
+
+// addPage posts a new page at the end of the thread, and remembers it.
+func (ld *leaderboardData) addPage(s *discordgo.Session, content string) error {
+	msg, err := s.ChannelMessageSend(ld.channelID, content)
+	if err != nil {
+		return err
+	}
+	ld.msgs = append(ld.msgs, newDiscordMessage(msg))
+	if err := ld.save(); err != nil {
+		log.Printf("failed to save pages of leaderboard %v: %v", ld.channelID, err)
+	}
+	return nil
+}
+
+// compact deletes the pages left unused at the end of the leaderboard, which should be freshly rendered.
+// The first page starts the thread and is never deleted.
+func (ld *leaderboardData) compact(s *discordgo.Session) error {
+	for len(ld.msgs) > 1 && ld.msgs[len(ld.msgs)-1].content == placeholderMessage {
+		last := ld.msgs[len(ld.msgs)-1]
+		if err := s.ChannelMessageDelete(ld.channelID, last.id); err != nil {
+			return err
+		}
+		ld.msgs = ld.msgs[:len(ld.msgs)-1]
+	}
+	return ld.save()
+}
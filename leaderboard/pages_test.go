@@ -0,0 +1,74 @@
+package leaderboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestIsPage(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{initialMessage, true},
+		{placeholderMessage, true},
+		{staleMessagePrefix + "\n1\\. <@111> (?)", true},
+		{unknownRankMessagePrefix + "\n- <@111>", true},
+		{conflictsMessagePrefix + "\n- <@111> is ranked", true},
+		{instructionsMessagePrefix + "\nAdd master rank players", true},
+		{"12\\. <@111> (~77k)", true},
+		{"- <@111>", true},
+		{"<@111> <@222>\nYour rank on this leaderboard was reported more than 30 days ago", false},
+		{"Hello", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isPage(tt.content); got != tt.want {
+			t.Errorf("isPage(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestRenderedElsewhere(t *testing.T) {
+	edited := time.Date(2026, 10, 19, 10, 0, 0, 123456000, time.UTC)
+	b := board{Pages: []page{{ID: "1"}, {ID: "2", Edited: edited}}}
+
+	tests := []struct {
+		name string
+		msgs []discordMessage
+		want bool
+	}{
+		{"unchanged", []discordMessage{{id: "1"}, {id: "2", edited: edited}}, false},
+		{"same time elsewhere", []discordMessage{{id: "1"}, {id: "2", edited: edited.In(time.FixedZone("CEST", 2*3600))}}, false},
+		{"page gone", []discordMessage{{id: "2", edited: edited}}, false},
+		{"edited since", []discordMessage{{id: "1"}, {id: "2", edited: edited.Add(time.Second)}}, true},
+		{"edited for the first time", []discordMessage{{id: "1", edited: edited}, {id: "2", edited: edited}}, true},
+	}
+	for _, tt := range tests {
+		if got := b.renderedElsewhere(tt.msgs); got != tt.want {
+			t.Errorf("%s: renderedElsewhere = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReimport(t *testing.T) {
+	b := board{
+		Ranked:   []entry{{userID: "111", rank: 1, timestamp: 100, notified: true}},
+		History:  []entry{{userID: "111", rank: 1, timestamp: 100}},
+		Revision: 3,
+	}
+	msgs := []*discordgo.Message{{Content: leaderboardMessagePrefix + "\n1\\. <@111> (?) — <t:100:R>\n2\\. <@222> (?) — <t:50:R>"}}
+
+	got := b.reimport(msgs)
+	if len(got.Ranked) != 2 || !got.Ranked[0].notified || got.Ranked[1].notified {
+		t.Errorf("ranked = %+v, want both players, only the first one pinged", got.Ranked)
+	}
+	if len(got.History) != 2 || got.History[0].userID != "222" || got.History[1].userID != "111" {
+		t.Errorf("history = %+v, want the report of the second player added first", got.History)
+	}
+	if got.Revision != b.Revision {
+		t.Errorf("revision = %d, want %d", got.Revision, b.Revision)
+	}
+}
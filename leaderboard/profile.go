@@ -0,0 +1,92 @@
+package leaderboard
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+)
+
+// playerProfile describes where a player stands in the latest season, and the best rank they had in the others.
+func playerProfile(s *discordgo.Session, i *discordgo.InteractionCreate, query string) (string, error) {
+	p := i18n.Printer(i.Interaction)
+
+	player, err := resolvePlayer(s, i, query)
+	if err != nil {
+		return "", err
+	}
+	seasons, err := allSeasonThreads(s, i.GuildID, i.AppID)
+	if err != nil {
+		return "", err
+	}
+	numbers := latestFirst(seasons)
+	if len(numbers) == 0 {
+		return p.Sprintf("No leaderboards yet."), nil
+	}
+
+	msg := p.Sprintf("Profile of %s:", player.player())
+
+	current := seasons[numbers[0]]
+	b, err := loadBoard(s, current)
+	if err != nil {
+		return "", err
+	}
+	if k := slices.IndexFunc(b.Ranked, player.samePlayer); k >= 0 {
+		e := b.Ranked[k]
+		msg += "\n" + p.Sprintf("**%s**: #%d %s", current.Name, e.rank, e.formatPoints(p))
+		reporter := e.reporterID
+		if reporter == "" {
+			reporter = e.userID
+		}
+		switch {
+		case e.timestamp > 0 && reporter != "":
+			msg += "\n" + p.Sprintf("Reported %s by %s.", fmt.Sprintf("<t:%d:R>", e.timestamp), userMention(reporter))
+		case e.timestamp > 0:
+			msg += "\n" + p.Sprintf("Reported %s.", fmt.Sprintf("<t:%d:R>", e.timestamp))
+		}
+	} else if slices.ContainsFunc(b.Unordered, player.samePlayer) {
+		// as listed in the thread
+		others := slices.Clone(b.Unordered)
+		slices.SortFunc(others, func(a, b entry) int { return cmp.Compare(a.String(), b.String()) })
+		k := slices.IndexFunc(others, player.samePlayer)
+		msg += "\n" + p.Sprintf("**%s**: among the other masters (%d of %d)", current.Name, k+1, len(others))
+	} else {
+		msg += "\n" + p.Sprintf("**%s**: not on the leaderboard", current.Name)
+	}
+
+	var past []string
+	for _, n := range numbers[1:] {
+		b, err := loadBoard(s, seasons[n])
+		if err != nil {
+			return "", err
+		}
+		reports := b.reportsOf(player)
+		if len(reports) == 0 {
+			continue
+		}
+		best := 0
+		for _, r := range reports {
+			if r.rank > 0 && (best == 0 || r.rank < best) {
+				best = r.rank
+			}
+		}
+		if best > 0 {
+			past = append(past, fmt.Sprintf("- %s: #%d", seasons[n].Name, best))
+		} else {
+			past = append(past, fmt.Sprintf("- %s: %s", seasons[n].Name, p.Sprintf("other master")))
+		}
+	}
+	if len(past) > 0 {
+		msg += "\n\n" + p.Sprintf("Best ranks in past seasons:")
+		for _, l := range past {
+			if len(msg)+len(l)+len("\n\n…") > discordMessageCharacterLimit {
+				msg += "\n…"
+				break
+			}
+			msg += "\n" + l
+		}
+	}
+	return msg, nil
+}
@@ -0,0 +1,109 @@
+package leaderboard
+
+import (
+	"log"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+)
+
+// how many updates may wait for a leaderboard. interaction tokens only last 15 minutes, so no point in more.
+const queueLength = 100
+
+// the fetch messages-update messages operation is very much non-atomic, so races could be bad:
+// updates to the leaderboard of a season are applied one after another, in the order they came in.
+var (
+	queuesMutex sync.Mutex
+	queues      = make(map[string]chan func())
+)
+
+// enqueue reports whether the job was queued, or there were too many already.
+func enqueue(threadID string, job func()) bool {
+	queuesMutex.Lock()
+	defer queuesMutex.Unlock()
+	q, ok := queues[threadID]
+	if !ok {
+		q = make(chan func(), queueLength)
+		queues[threadID] = q
+		go work(threadID, q)
+	}
+
+	select {
+	case q <- job:
+		return true
+	default:
+		return false
+	}
+}
+
+// work applies the jobs queued for the thread one after another, and stops once there are none left.
+func work(threadID string, q chan func()) {
+	for {
+		var job func()
+		queuesMutex.Lock()
+		select {
+		case job = <-q:
+		default:
+			delete(queues, threadID)
+		}
+		queuesMutex.Unlock()
+		if job == nil {
+			return
+		}
+		job()
+	}
+}
+
+// queueUpdate acknowledges the interaction right away, as finding the thread of the leaderboard may take longer
+// than Discord waits. It then tells the caller their update is queued, or why not if find returns no thread,
+// applies it once all earlier ones are done, and follows up with how it went.
+func queueUpdate(s *discordgo.Session, i *discordgo.InteractionCreate, find func() (*discordgo.Channel, string), update func(thread *discordgo.Channel) string) error {
+	p := i18n.Printer(i.Interaction)
+
+	err := s.InteractionRespond(i.Interaction,
+		&discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		respond := func(msg string) {
+			if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &msg}); err != nil {
+				log.Printf("failed to respond to update of a leaderboard: %v", err)
+			}
+		}
+
+		thread, msg := find()
+		if thread == nil {
+			respond(msg)
+			return
+		}
+
+		acked := make(chan struct{})
+		queued := enqueue(thread.ID, func() {
+			<-acked // cannot follow up before the reply
+			msg := update(thread)
+			_, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+				Content: msg,
+				Flags:   discordgo.MessageFlagsEphemeral,
+			})
+			if err != nil {
+				log.Printf("failed to follow up on update of leaderboard %v: %v", thread.ID, err)
+			}
+		})
+		defer close(acked)
+
+		msg = p.Sprintf("Queued! You will be told here once %s is updated.", channelMention(thread.ID))
+		if !queued {
+			msg = p.Sprintf("Too many updates waiting for %s, sorry; try again later.", channelMention(thread.ID))
+		}
+		respond(msg)
+	}()
+	return nil
+}
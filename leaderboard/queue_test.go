@@ -0,0 +1,52 @@
+package leaderboard
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEnqueue(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		done []int
+		wg   sync.WaitGroup
+	)
+	for k := range 10 {
+		wg.Add(1)
+		if !enqueue("thread", func() {
+			defer wg.Done()
+			mu.Lock()
+			done = append(done, k)
+			mu.Unlock()
+		}) {
+			t.Fatalf("job %d not queued", k)
+		}
+	}
+	wg.Wait()
+	for k, d := range done {
+		if d != k {
+			t.Fatalf("jobs ran in order %v", done)
+		}
+	}
+
+	// the worker stops once the queue is empty
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		queuesMutex.Lock()
+		_, ok := queues["thread"]
+		queuesMutex.Unlock()
+		if !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("queue still there once empty")
+		}
+	}
+
+	// and a new one starts with the next job
+	ran := make(chan struct{})
+	if !enqueue("thread", func() { close(ran) }) {
+		t.Fatal("job not queued")
+	}
+	<-ran
+}
@@ -2,7 +2,6 @@ package leaderboard
 
 import (
 	"fmt"
-	"math"
 	"strconv"
 	"strings"
 
@@ -20,57 +19,148 @@ type entry struct {
 	guess      bool
 	reporterID string
 	timestamp  int
+
+	// whether the player was asked to confirm their rank once it went stale
+	notified bool
 }
 
-func (r entry) String() string {
-	player := r.name
+func (r entry) player() string {
 	if r.userID != "" {
-		player = userMention(r.userID)
+		return userMention(r.userID)
 	}
+	return r.name
+}
 
+func (r entry) samePlayer(o entry) bool {
+	if r.userID != "" || o.userID != "" {
+		return r.userID == o.userID
+	}
+	return r.name == o.name
+}
+
+// sameReport tells whether both entries come from the same report of the same player.
+func (r entry) sameReport(o entry) bool {
+	return r.samePlayer(o) && r.timestamp == o.timestamp
+}
+
+func (r entry) String() string {
+	return r.format(message.NewPrinter(language.English))
+}
+
+// format renders the entry as a line of the leaderboard, which parseEntry reads back whatever the language.
+// The timestamp is left out if unknown, as for entries imported without one.
+func (r entry) format(p *message.Printer) string {
+	player := r.player()
 	if r.rank == 0 {
 		return player
 	}
 
-	p := message.NewPrinter(language.English)
-	pts := "(?)"
-	if r.points > 0 {
-		rounded := int(math.Round(float64(r.points) / 1000.))
-		if r.guess {
-			pts = p.Sprintf("(~%dk?)", number.Decimal(rounded))
-		} else if r.approx {
-			pts = p.Sprintf("(~%dk)", number.Decimal(rounded))
-		} else {
-			pts = p.Sprintf("(%d)", number.Decimal(r.points))
-		}
+	line := fmt.Sprintf("%d\\. %s %s", r.rank, player, r.formatPoints(p))
+	if r.timestamp > 0 {
+		line += fmt.Sprintf(" — <t:%d:R>", r.timestamp)
 	}
-	reporter := ""
 	if r.reporterID != "" {
-		reporter = " by " + userMention(r.reporterID)
+		line += " (" + userMention(r.reporterID) + ")"
 	}
+	return line
+}
 
-	return fmt.Sprintf("%d\\. %s %s — <t:%d:R>%s", r.rank, player, pts, r.timestamp, reporter)
+func (r entry) formatPoints(p *message.Printer) string {
+	if r.points <= 0 {
+		return "(?)"
+	}
+	// approximations are shown in thousands when that loses nothing
+	pts := p.Sprintf("%d", number.Decimal(r.points))
+	if (r.approx || r.guess) && r.points%1000 == 0 {
+		pts = p.Sprintf("%dk", number.Decimal(r.points/1000))
+	}
+	if r.approx {
+		pts = "~" + pts
+	}
+	if r.guess {
+		pts += "?"
+	}
+	return "(" + pts + ")"
 }
 
-func getRankAndName(raw string) (rank int, nameOrID string, isID bool) {
-	sep := "\\. "
-	i := strings.Index(raw, "\\. ")
-	if i >= 0 {
-		rank, _ = strconv.Atoi(raw[:i])
-		raw = raw[i+len(sep):]
+// parseEntry recovers an entry from a line rendered by format, or by versions predating the store.
+// Those named the reporter in English, and rounded approximate points, which can of course not be
+// recovered exactly but render the same again.
+func parseEntry(line string) entry {
+	var ent entry
+
+	if before, after, ok := strings.Cut(line, "\\. "); ok {
+		ent.rank, _ = strconv.Atoi(before)
+		line = after
 	}
+	line = strings.TrimSuffix(line, " "+staleMarker)
 
-	i = strings.LastIndex(raw, " (")
-	if i >= 0 {
-		raw = raw[:i]
+	if i := strings.LastIndex(line, " (<@"); i >= 0 && strings.HasSuffix(line, ")") {
+		if id, ok := mentionedID(line[i+len(" (") : len(line)-1]); ok {
+			ent.reporterID = id
+			line = line[:i]
+		}
+	} else if i := strings.LastIndex(line, " by <@"); i >= 0 {
+		// as rendered before the store
+		if id, ok := mentionedID(line[i+len(" by "):]); ok {
+			ent.reporterID = id
+			line = line[:i]
+		}
 	}
 
-	nameOrID = raw
-	raw = strings.TrimSuffix(strings.TrimPrefix(raw, "<@"), ">")
-	if _, err := strconv.Atoi(raw); err == nil {
-		nameOrID = raw
-		isID = true
+	if i := strings.LastIndex(line, " — <t:"); i >= 0 {
+		ts := strings.TrimSuffix(line[i+len(" — <t:"):], ":R>")
+		if t, err := strconv.Atoi(ts); err == nil {
+			ent.timestamp = t
+			line = line[:i]
+		}
 	}
 
-	return rank, nameOrID, isID
+	if i := strings.LastIndex(line, " ("); i >= 0 && strings.HasSuffix(line, ")") {
+		ent.points, ent.approx, ent.guess = parsePoints(line[i+len(" (") : len(line)-1])
+		line = line[:i]
+	}
+
+	if id, ok := mentionedID(line); ok {
+		ent.userID = id
+	} else {
+		ent.name = line
+	}
+	return ent
+}
+
+// parsePoints reads points as rendered, e.g. "35,123", "~77k" or "~180k?".
+func parsePoints(s string) (points int, approx, guess bool) {
+	s, guess = strings.CutSuffix(s, "?")
+	s, approx = strings.CutPrefix(s, "~")
+	s, thousands := strings.CutSuffix(s, "k")
+	digits := strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, s)
+	points, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, false, false
+	}
+	if thousands {
+		points *= 1000
+	}
+	return points, approx, guess
+}
+
+func mentionedID(s string) (string, bool) {
+	s, ok := strings.CutPrefix(s, "<@")
+	if !ok {
+		return "", false
+	}
+	s, ok = strings.CutSuffix(s, ">")
+	if !ok {
+		return "", false
+	}
+	if _, err := strconv.ParseUint(s, 10, 64); err != nil {
+		return "", false
+	}
+	return s, true
 }
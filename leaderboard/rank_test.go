@@ -0,0 +1,120 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestParseEntryBaseline(t *testing.T) {
+	tests := []struct {
+		line     string
+		want     entry
+		rendered string
+	}{
+		{
+			line:     "12\\. <@111> (~77k) — <t:1700000000:R> by <@222>",
+			want:     entry{userID: "111", rank: 12, points: 77000, approx: true, reporterID: "222", timestamp: 1700000000},
+			rendered: "12\\. <@111> (~77k) — <t:1700000000:R> (<@222>)",
+		},
+		{
+			line:     "3\\. <@111> (35,123) — <t:1700000000:R>",
+			want:     entry{userID: "111", rank: 3, points: 35123, timestamp: 1700000000},
+			rendered: "3\\. <@111> (35,123) — <t:1700000000:R>",
+		},
+		{
+			line:     "4\\. Some Name (~180k?) — <t:1700000000:R> by <@222>",
+			want:     entry{name: "Some Name", rank: 4, points: 180000, approx: true, guess: true, reporterID: "222", timestamp: 1700000000},
+			rendered: "4\\. Some Name (~180k?) — <t:1700000000:R> (<@222>)",
+		},
+		{
+			line:     "5\\. <@111> (?) — <t:1700000000:R>",
+			want:     entry{userID: "111", rank: 5, timestamp: 1700000000},
+			rendered: "5\\. <@111> (?) — <t:1700000000:R>",
+		},
+		{
+			line:     "<@111>",
+			want:     entry{userID: "111"},
+			rendered: "<@111>",
+		},
+	}
+	for _, tt := range tests {
+		got := parseEntry(tt.line)
+		if got != tt.want {
+			t.Errorf("parseEntry(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+		if r := got.String(); r != tt.rendered {
+			t.Errorf("parseEntry(%q) renders as %q, want %q", tt.line, r, tt.rendered)
+		}
+	}
+}
+
+func TestEntryRoundTrip(t *testing.T) {
+	entries := []entry{
+		{userID: "111", rank: 4, points: 77123, approx: true, reporterID: "222", timestamp: 1700000000},
+		{name: "Name (with parentheses)", rank: 2, points: 180000, guess: true},
+		{name: "Name", rank: 2, points: 180000, approx: true, guess: true},
+		{userID: "111", rank: 1, points: 35123, reporterID: "222"},
+		{userID: "111", rank: 3},
+		{userID: "111", rank: 3, timestamp: 1700000000, reporterID: "222"},
+	}
+	for _, tag := range []language.Tag{language.English, language.French, language.German} {
+		p := message.NewPrinter(tag)
+		for _, e := range entries {
+			line := e.format(p)
+			if got := parseEntry(line); got != e {
+				t.Errorf("%v: parseEntry(%q) = %+v, want %+v", tag, line, got, e)
+			}
+			if got := parseEntry(line + " " + staleMarker); got != e {
+				t.Errorf("%v: parseEntry(%q) with stale marker = %+v, want %+v", tag, line, got, e)
+			}
+		}
+	}
+}
+
+func TestParsePoints(t *testing.T) {
+	tests := []struct {
+		in            string
+		points        int
+		approx, guess bool
+	}{
+		{"35,123", 35123, false, false},
+		{"35 123", 35123, false, false},
+		{"35.123", 35123, false, false},
+		{"~77k", 77000, true, false},
+		{"~180k?", 180000, true, true},
+		{"180,000?", 180000, false, true},
+		{"?", 0, false, false},
+	}
+	for _, tt := range tests {
+		points, approx, guess := parsePoints(tt.in)
+		if points != tt.points || approx != tt.approx || guess != tt.guess {
+			t.Errorf("parsePoints(%q) = %d, %v, %v, want %d, %v, %v", tt.in, points, approx, guess, tt.points, tt.approx, tt.guess)
+		}
+	}
+}
+
+func TestEntryRawMigration(t *testing.T) {
+	var e entry
+	if err := json.Unmarshal([]byte(`{"raw":"12\\. <@111> (~77k) — <t:1700000000:R> by <@222>"}`), &e); err != nil {
+		t.Fatal(err)
+	}
+	want := entry{userID: "111", rank: 12, points: 77000, approx: true, reporterID: "222", timestamp: 1700000000}
+	if e != want {
+		t.Errorf("got %+v, want %+v", e, want)
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back entry
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back != want {
+		t.Errorf("JSON round trip: got %+v, want %+v", back, want)
+	}
+}
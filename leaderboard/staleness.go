@@ -0,0 +1,172 @@
+package leaderboard
+
+import (
+	"log"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+	"github.com/itizir/hrv/store"
+	"golang.org/x/text/message"
+)
+
+const (
+	settingsCollection = "leaderboard_settings"
+
+	staleMessagePrefix = "# Stale Ranks"
+	staleMarker        = "⏳"
+
+	// how often CheckStaleness looks for ranks that went stale
+	stalenessCheckInterval = time.Hour
+)
+
+// settings of the leaderboards of a guild
+type settings struct {
+	// ranks reported longer ago than this are stale. zero means never.
+	StaleDays int `json:"stale_days,omitempty"`
+	// list stale ranks in a section of their own, instead of just marking them
+	SeparateStale bool `json:"separate_stale,omitempty"`
+}
+
+func loadSettings(guildID string) (settings, error) {
+	var st settings
+	_, err := store.Get(settingsCollection, guildID, &st)
+	return st, err
+}
+
+func saveSettings(guildID string, st settings) error {
+	return store.Put(settingsCollection, guildID, st)
+}
+
+// stale tells whether the rank was reported too long ago. Ranks imported without a time of report never are.
+func (st settings) stale(e entry, now time.Time) bool {
+	if st.StaleDays <= 0 || e.rank == 0 || e.timestamp == 0 {
+		return false
+	}
+	return now.Sub(time.Unix(int64(e.timestamp), 0)) > time.Duration(st.StaleDays)*24*time.Hour
+}
+
+// notifyStale asks the players whose rank went stale, and who were not asked yet, to confirm or update it.
+// Only once pinged are they recorded as such, so that those a ping failed to reach are pinged on a later update.
+// Reporting a rank again starts over with a fresh entry, so players are pinged at most once per report.
+func (ld *leaderboardData) notifyStale(s *discordgo.Session, now time.Time) {
+	var stale []entry
+	for _, e := range ld.Ranked {
+		if !e.notified && ld.settings.stale(e, now) {
+			stale = append(stale, e)
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+
+	pinged := ld.pingStale(s, stale)
+	for k, e := range ld.Ranked {
+		if slices.ContainsFunc(pinged, e.sameReport) {
+			ld.Ranked[k].notified = true
+		}
+	}
+	if err := ld.save(); err != nil {
+		log.Printf("failed to save the players pinged about stale ranks of leaderboard %v: %v", ld.channelID, err)
+	}
+}
+
+// pingStale asks players to confirm or update their stale ranks, in as few messages as possible,
+// and returns those it got to. Players only known by name can not be pinged, and count as done.
+func (ld *leaderboardData) pingStale(s *discordgo.Session, players []entry) []entry {
+	var done, batch []entry
+	for _, e := range players {
+		if e.userID == "" {
+			done = append(done, e)
+		}
+	}
+	text := ld.printer.Sprintf("Your rank on this leaderboard was reported more than %d days ago: please confirm or update it with `/rank report`!", ld.settings.StaleDays)
+
+	var mentions []string
+	send := func() {
+		if len(mentions) == 0 {
+			return
+		}
+		msg := strings.Join(mentions, " ") + "\n" + text
+		if _, err := s.ChannelMessageSend(ld.channelID, msg); err != nil {
+			log.Printf("failed to ping stale players of leaderboard %v: %v", ld.channelID, err)
+		} else {
+			done = append(done, batch...)
+		}
+		mentions, batch = nil, nil
+	}
+	length := len(text) + 1
+	for _, e := range players {
+		if e.userID == "" {
+			continue
+		}
+		m := userMention(e.userID)
+		if length+len(m)+1 > discordMessageCharacterLimit {
+			send()
+			length = len(text) + 1
+		}
+		mentions = append(mentions, m)
+		batch = append(batch, e)
+		length += len(m) + 1
+	}
+	send()
+	return done
+}
+
+// whether CheckStaleness runs, or ranks going stale are only noticed on updates
+var checkingStaleness atomic.Bool
+
+// CheckStaleness keeps looking for ranks that went stale on the current leaderboard of every guild,
+// as those are otherwise only noticed when the leaderboard is updated. It needs a session connected
+// to the gateway, which knows the bot user, and never returns.
+func CheckStaleness(s *discordgo.Session) {
+	checkingStaleness.Store(true)
+	for {
+		checkStaleness(s)
+		time.Sleep(stalenessCheckInterval)
+	}
+}
+
+func checkStaleness(s *discordgo.Session) {
+	guildIDs, err := store.Keys(settingsCollection)
+	if err != nil {
+		log.Println("failed to list leaderboard settings to check staleness:", err)
+		return
+	}
+	now := time.Now()
+	for _, guildID := range guildIDs {
+		st, err := loadSettings(guildID)
+		if err != nil || st.StaleDays <= 0 {
+			continue
+		}
+		thread, _, err := getSeasonThread(s, guildID, s.State.User.ID, -1)
+		if err != nil {
+			log.Printf("failed to find the current leaderboard of guild %v to check staleness: %v", guildID, err)
+			continue
+		}
+		b, err := loadBoard(s, thread)
+		if err != nil {
+			log.Printf("failed to load leaderboard %v to check staleness: %v", thread.ID, err)
+			continue
+		}
+		if !slices.ContainsFunc(b.Ranked, func(e entry) bool { return !e.notified && st.stale(e, now) }) {
+			continue
+		}
+
+		p := i18n.Default()
+		if g, err := s.Guild(guildID); err == nil {
+			p = message.NewPrinter(i18n.Tag(discordgo.Locale(g.PreferredLocale)))
+		}
+		queued := enqueue(thread.ID, func() {
+			if _, err := updateLeaderboard(s, thread, p, func(*leaderboardData) error { return nil }); err != nil {
+				log.Printf("failed to update stale ranks of leaderboard %v: %v", thread.ID, err)
+			}
+		})
+		if !queued {
+			log.Printf("too many updates waiting for leaderboard %v to update its stale ranks", thread.ID)
+		}
+	}
+}
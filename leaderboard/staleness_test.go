@@ -0,0 +1,54 @@
+package leaderboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itizir/hrv/i18n"
+	"github.com/itizir/hrv/store"
+)
+
+func TestStale(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) int { return int(now.Add(-time.Duration(d) * 24 * time.Hour).Unix()) }
+
+	tests := []struct {
+		name string
+		st   settings
+		e    entry
+		want bool
+	}{
+		{"never stale", settings{}, entry{rank: 1, timestamp: daysAgo(400)}, false},
+		{"recent", settings{StaleDays: 30}, entry{rank: 1, timestamp: daysAgo(29)}, false},
+		{"old", settings{StaleDays: 30}, entry{rank: 1, timestamp: daysAgo(31)}, true},
+		{"unranked", settings{StaleDays: 30}, entry{timestamp: daysAgo(31)}, false},
+		{"imported without time", settings{StaleDays: 30}, entry{rank: 1}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.st.stale(tt.e, now); got != tt.want {
+			t.Errorf("%s: stale = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNotifyStale(t *testing.T) {
+	store.Dir = t.TempDir()
+	now := time.Now()
+	old := int(now.Add(-40 * 24 * time.Hour).Unix())
+
+	ld := leaderboardData{guildID: "g", channelID: "c", settings: settings{StaleDays: 30}, printer: i18n.Default()}
+	ld.Ranked = []entry{
+		{name: "Unpingable", rank: 1, timestamp: old},
+		{name: "Recent", rank: 2, timestamp: int(now.Unix())},
+	}
+	// players only known by name need no ping, and so no session
+	ld.notifyStale(nil, now)
+	if !ld.Ranked[0].notified || ld.Ranked[1].notified {
+		t.Fatalf("ranked = %+v, want only the stale player recorded as notified", ld.Ranked)
+	}
+
+	b, ok, err := Boards.Load("g", "c")
+	if err != nil || !ok || !b.Ranked[0].notified {
+		t.Errorf("stored board = %+v, %v, %v, want the stale player recorded as notified", b, ok, err)
+	}
+}
@@ -0,0 +1,132 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/itizir/hrv/store"
+)
+
+const boardsCollection = "leaderboards"
+
+// Store holds the leaderboards, one per season thread. It is the source of truth:
+// the messages in the thread are only a view of it, which can be rebuilt at any time.
+type Store interface {
+	// Load reports whether the leaderboard of the thread was stored yet.
+	Load(guildID, threadID string) (board, bool, error)
+	// Save bumps the revision of the leaderboard. It fails with errConflict if the stored
+	// revision is no longer the one the leaderboard was loaded with, as another instance of the bot saved it since.
+	Save(guildID, threadID string, b *board) error
+}
+
+var errConflict = errors.New("leaderboard was changed concurrently")
+
+// Boards is where leaderboards are kept, as JSON documents under the data directory by default.
+var Boards Store = fileStore{}
+
+type board struct {
+	Ranked    []entry `json:"ranked"`
+	Unordered []entry `json:"unordered"`
+
+	// the messages the leaderboard is rendered into, in order
+	Pages []page `json:"pages,omitempty"`
+
+	// every report accepted, oldest first
+	History []entry `json:"history,omitempty"`
+
+	// keys of the conflicts admins chose to leave as they are
+	Dismissed []string `json:"dismissed,omitempty"`
+
+	Revision int `json:"revision,omitempty"`
+}
+
+// page is a message the leaderboard is rendered into, with when rendering it last edited the message.
+// Instances of the bot that do not share their store tell from a later edit that another one rendered it since.
+type page struct {
+	ID     string    `json:"id"`
+	Edited time.Time `json:"edited"`
+}
+
+type fileStore struct{}
+
+func boardsCollectionOf(guildID string) string {
+	return store.Collection(boardsCollection, guildID)
+}
+
+func (fileStore) Load(guildID, threadID string) (board, bool, error) {
+	var b board
+	ok, err := store.Get(boardsCollectionOf(guildID), threadID, &b)
+	return b, ok, err
+}
+
+// Save only guards against other processes sharing the data directory on a best effort basis:
+// one could still save in between the check and the write, but the messages are checked too before editing them.
+func (fileStore) Save(guildID, threadID string, b *board) error {
+	var current board
+	if _, err := store.Get(boardsCollectionOf(guildID), threadID, &current); err != nil {
+		return err
+	}
+	if current.Revision != b.Revision {
+		return errConflict
+	}
+	b.Revision++
+	if err := store.Put(boardsCollectionOf(guildID), threadID, b); err != nil {
+		b.Revision--
+		return err
+	}
+	return nil
+}
+
+// storedEntry mirrors entry, whose fields are not exported.
+type storedEntry struct {
+	UserID     string `json:"user_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Rank       int    `json:"rank,omitempty"`
+	Points     int    `json:"points,omitempty"`
+	Approx     bool   `json:"approx,omitempty"`
+	Guess      bool   `json:"guess,omitempty"`
+	ReporterID string `json:"reporter_id,omitempty"`
+	Timestamp  int    `json:"timestamp,omitempty"`
+	Notified   bool   `json:"notified,omitempty"`
+
+	// lines imported verbatim by earlier versions, parsed when loaded
+	Raw string `json:"raw,omitempty"`
+}
+
+func (r entry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(storedEntry{
+		UserID:     r.userID,
+		Name:       r.name,
+		Rank:       r.rank,
+		Points:     r.points,
+		Approx:     r.approx,
+		Guess:      r.guess,
+		ReporterID: r.reporterID,
+		Timestamp:  r.timestamp,
+		Notified:   r.notified,
+	})
+}
+
+func (r *entry) UnmarshalJSON(b []byte) error {
+	var se storedEntry
+	if err := json.Unmarshal(b, &se); err != nil {
+		return err
+	}
+	if se.Raw != "" {
+		*r = parseEntry(se.Raw)
+		return nil
+	}
+	*r = entry{
+		userID:     se.UserID,
+		name:       se.Name,
+		rank:       se.Rank,
+		points:     se.Points,
+		approx:     se.Approx,
+		guess:      se.Guess,
+		reporterID: se.ReporterID,
+		timestamp:  se.Timestamp,
+		notified:   se.Notified,
+	}
+	return nil
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
 )
 
 func init() {
@@ -25,6 +29,7 @@ const (
 
 	leaderboardMessagePrefix  = "# Leaderboard"
 	unknownRankMessagePrefix  = "# Other Masters"
+	conflictsMessagePrefix    = "# Needs Attention"
 	instructionsMessagePrefix = "# Instructions"
 
 	// OK, hardcoded MYM joke right there... But also probably accurate starting point.
@@ -34,77 +39,160 @@ const (
 	numPlaceholderMessages = 5
 )
 
-func instructionsMessage(appID string) string {
-	return instructionsMessagePrefix + `
-Add master rank players to the leaderboard by calling ` + userMention(appID) + `'s ` + "`rank`" + ` command (that can be invoked by simply typing ` + "`/rank`" + ` from anywhere on this server).
-Make sure not to confuse other bots' ` + "`/rank`" + ` with ` + userMention(appID) + `'s!
+// the header is kept untranslated, as it is used to find the end of the leaderboard when parsing messages
+func instructionsMessage(p *message.Printer, appID string) string {
+	return instructionsMessagePrefix + "\n" + p.Sprintf(instructionsMessageBody, userMention(appID))
+}
+
+const instructionsMessageBody = `Add master rank players to the leaderboard by calling %[1]s's ` + "`rank`" + ` command (that can be invoked by simply typing ` + "`/rank report`" + ` from anywhere on this server).
+Make sure not to confuse other bots' ` + "`/rank`" + ` with %[1]s's!
 
 You may optionally report exact (e.g. ` + "`35123`" + `), approximate (e.g. ` + "`~77000`" + `), or guessed (e.g. ` + "`180000?`" + `) rank points.
 
 If reporting for another Discord member, it isn't necessary to enter their whole name or username as long as it unambiguously identifies them; priority will be given to exact _username_ match.`
-}
 
 func getSeasonThread(s *discordgo.Session, guildID, authorID string, season int) (*discordgo.Channel, int, error) {
+	seasons, err := seasonThreads(s, guildID, authorID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if season >= 0 {
+		if thr, ok := seasons[season]; ok {
+			return thr, season, nil
+		}
+		return nil, 0, fmt.Errorf("season %d not tracked", season)
+	}
+
 	latestSeason := -1
-	var latest *discordgo.Channel
-	selectThread := func(thrs []*discordgo.Channel) *discordgo.Channel {
+	for i := range seasons {
+		latestSeason = max(latestSeason, i)
+	}
+	if latestSeason < 0 {
+		return nil, 0, errors.New("failed to find any active leaderboards")
+	}
+
+	return seasons[latestSeason], latestSeason, nil
+}
+
+// seasonThreads returns the unlocked leaderboard threads created by authorID, by season number.
+// Locked threads are those of closed seasons, which must no longer change.
+func seasonThreads(s *discordgo.Session, guildID, authorID string) (map[int]*discordgo.Channel, error) {
+	return listSeasonThreads(s, guildID, authorID, false)
+}
+
+// allSeasonThreads returns the leaderboard threads created by authorID, closed seasons included, by season number.
+// Only commands that do not change leaderboards may use it.
+func allSeasonThreads(s *discordgo.Session, guildID, authorID string) (map[int]*discordgo.Channel, error) {
+	return listSeasonThreads(s, guildID, authorID, true)
+}
+
+// how long the leaderboard threads of a guild are remembered: listing them takes a request per page of archived threads,
+// which autocomplete can not afford on every keystroke. Seasons started by the bot are known right away,
+// but a season closed by locking its thread may still take reports for that long.
+const seasonThreadsTTL = 30 * time.Second
+
+type cachedThreads struct {
+	threads []*discordgo.Channel
+	fetched time.Time
+}
+
+var (
+	threadsCacheMutex sync.Mutex
+	threadsCache      = make(map[string]cachedThreads)
+)
+
+func listSeasonThreads(s *discordgo.Session, guildID, authorID string, withLocked bool) (map[int]*discordgo.Channel, error) {
+	thrs, err := leaderboardThreads(s, guildID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	seasons := make(map[int]*discordgo.Channel)
+	for _, thr := range thrs {
+		if thr.ThreadMetadata.Locked && !withLocked {
+			continue
+		}
+		if i, ok := seasonNumber(thr.Name); ok {
+			seasons[i] = thr
+		}
+	}
+	return seasons, nil
+}
+
+// leaderboardThreads returns the threads created by authorID in the leaderboards forum, active ones first.
+func leaderboardThreads(s *discordgo.Session, guildID, authorID string) ([]*discordgo.Channel, error) {
+	key := guildID + "/" + authorID
+	threadsCacheMutex.Lock()
+	cached, ok := threadsCache[key]
+	threadsCacheMutex.Unlock()
+	if ok && time.Since(cached.fetched) < seasonThreadsTTL {
+		return cached.threads, nil
+	}
+
+	var res []*discordgo.Channel
+	addThreads := func(thrs []*discordgo.Channel) {
 		for _, thr := range thrs {
-			if thr.ThreadMetadata.Locked || thr.OwnerID != authorID {
-				continue
-			}
-			name := strings.TrimPrefix(thr.Name, threadNamePrefix)
-			name = strings.Split(name, " ")[0]
-			i, err := strconv.Atoi(name)
-			if err != nil {
-				continue
-			}
-			if i == season {
-				return thr
-			}
-			if i > latestSeason {
-				latestSeason = i
-				latest = thr
+			if thr.OwnerID == authorID {
+				res = append(res, thr)
 			}
 		}
-		return nil
 	}
 
 	c, err := getLeaderboardsForum(s, guildID)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	thrs, err := s.ThreadsActive(c.ID)
 	if err != nil {
 		log.Printf("failed to fetch active threads in %v: %v", c.ID, err)
-		return nil, 0, errors.New("failed to fetch active threads")
-	}
-	if thr := selectThread(thrs.Threads); thr != nil {
-		return thr, season, nil
+		return nil, errors.New("failed to fetch active threads")
 	}
+	addThreads(thrs.Threads)
 
+	var before *time.Time
 	for {
-		thrs, err := s.ThreadsArchived(c.ID, nil, 0)
+		thrs, err := s.ThreadsArchived(c.ID, before, 0)
 		if err != nil {
 			log.Printf("failed to fetch archived threads in %v: %v", c.ID, err)
-			return nil, 0, errors.New("failed to fetch archived threads")
-		}
-		if thr := selectThread(thrs.Threads); thr != nil {
-			return thr, season, nil
+			return nil, errors.New("failed to fetch archived threads")
 		}
-		if !thrs.HasMore {
+		addThreads(thrs.Threads)
+		if !thrs.HasMore || len(thrs.Threads) == 0 {
 			break
 		}
+		archived := thrs.Threads[len(thrs.Threads)-1].ThreadMetadata.ArchiveTimestamp
+		before = &archived
 	}
 
-	if season >= 0 && season != latestSeason {
-		return nil, 0, fmt.Errorf("season %d not tracked", season)
-	}
-	if latest == nil {
-		return nil, 0, errors.New("failed to find any active leaderboards")
+	threadsCacheMutex.Lock()
+	threadsCache[key] = cachedThreads{threads: res, fetched: time.Now()}
+	threadsCacheMutex.Unlock()
+	return res, nil
+}
+
+// forgetThreads makes the next listing of the leaderboard threads of the guild fetch them again.
+func forgetThreads(guildID, authorID string) {
+	threadsCacheMutex.Lock()
+	delete(threadsCache, guildID+"/"+authorID)
+	threadsCacheMutex.Unlock()
+}
+
+func latestFirst(seasons map[int]*discordgo.Channel) []int {
+	var numbers []int
+	for n := range seasons {
+		numbers = append(numbers, n)
 	}
+	slices.Sort(numbers)
+	slices.Reverse(numbers)
+	return numbers
+}
 
-	return latest, latestSeason, nil
+func seasonNumber(threadName string) (int, bool) {
+	name := strings.TrimPrefix(threadName, threadNamePrefix)
+	name = strings.Split(name, " ")[0]
+	i, err := strconv.Atoi(name)
+	return i, err == nil
 }
 
 func getLeaderboardsForum(s *discordgo.Session, guildID string) (*discordgo.Channel, error) {
@@ -123,16 +211,14 @@ func getLeaderboardsForum(s *discordgo.Session, guildID string) (*discordgo.Chan
 	return nil, errors.New("could not find the leaderboards forum")
 }
 
-func createSeasonThread(s *discordgo.Session, guildID string, appID string, name string) error {
+func createSeasonThread(s *discordgo.Session, p *message.Printer, guildID string, appID string, name string) error {
 	if !strings.HasPrefix(name, threadNamePrefix) {
 		return fmt.Errorf("invalid season name (should start with %q)", threadNamePrefix)
 	}
 
-	str := strings.TrimPrefix(name, threadNamePrefix)
-	str = strings.Split(str, " ")[0]
-	i, err := strconv.Atoi(str)
-	if err != nil {
-		return fmt.Errorf("invalid season number: %w", err)
+	i, ok := seasonNumber(name)
+	if !ok {
+		return errors.New("invalid season number")
 	}
 	if i < 0 {
 		return errors.New("season number should not be negative")
@@ -147,8 +233,9 @@ func createSeasonThread(s *discordgo.Session, guildID string, appID string, name
 	if err != nil {
 		return err
 	}
+	forgetThreads(guildID, appID)
 
-	if _, err := s.ChannelMessageSend(thr.ID, instructionsMessage(appID)); err != nil {
+	if _, err := s.ChannelMessageSend(thr.ID, instructionsMessage(p, appID)); err != nil {
 		return err
 	}
 	for range numPlaceholderMessages {
@@ -8,6 +8,8 @@ import (
 	"os/signal"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/countvotes"
+	"github.com/itizir/hrv/leaderboard"
 )
 
 var (
@@ -20,11 +22,9 @@ var (
 	guildID  = flag.String("guild", "", "optionally restrict register/cleanup to a single guild")
 )
 
-func init() {
+func main() {
 	flag.Parse()
-}
 
-func main() {
 	var err error
 	pubKey, err = parsePubKey(pubKeyHex)
 	if err != nil {
@@ -59,6 +59,9 @@ func run() error {
 		return registerCommands(s, app.ID, *guildID)
 	}
 
+	// only warnings, no need to delay serving for them: cold starts in webhook mode should be quick
+	go countvotes.CheckEmojis(s)
+
 	if *wsMode {
 		s.AddHandler(interactionHandle)
 		err = s.Open()
@@ -67,6 +70,9 @@ func run() error {
 		}
 		defer s.Close()
 
+		// webhook mode only runs while handling interactions, so ranks there only go stale on updates
+		go leaderboard.CheckStaleness(s)
+
 		stop := make(chan os.Signal, 1)
 		signal.Notify(stop, os.Interrupt)
 		log.Println("Bot now connected and ready. Press Ctrl+C to exit...")
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"github.com/itizir/hrv/i18n"
+	"golang.org/x/text/language"
+)
+
+func init() {
+	i18n.RegisterNames(language.French, map[string]string{
+		"permissions": "permissions",
+		"allow":       "autoriser",
+		"deny":        "retirer",
+		"require":     "exiger",
+		"reset":       "réinitialiser",
+		"show":        "afficher",
+		"command":     "commande",
+		"role":        "rôle",
+		"permission":  "permission",
+	})
+	i18n.RegisterNames(language.German, map[string]string{
+		"permissions": "berechtigungen",
+		"allow":       "erlauben",
+		"deny":        "entziehen",
+		"require":     "erfordern",
+		"reset":       "zurücksetzen",
+		"show":        "anzeigen",
+		"command":     "befehl",
+		"role":        "rolle",
+		"permission":  "berechtigung",
+	})
+
+	i18n.Register(language.French, map[string]string{
+		"Control who may use the commands of the bot":                         "Choisir qui peut utiliser les commandes du bot",
+		"Let members with a role use a command":                               "Autoriser les membres ayant un rôle à utiliser une commande",
+		"Stop letting members with a role use a command":                      "Ne plus autoriser les membres ayant un rôle à utiliser une commande",
+		"Let members with a permission use a command":                         "Autoriser les membres ayant une permission à utiliser une commande",
+		"Permission members need, unless they have one of the allowed roles":  "Permission nécessaire, à moins d'avoir l'un des rôles autorisés",
+		"Go back to the default permissions of a command":                     "Revenir aux permissions par défaut d'une commande",
+		"Show who may use each command":                                       "Afficher qui peut utiliser chaque commande",
+		"Command, optionally followed by a subcommand, e.g. \"contest edit\"": "Commande, éventuellement suivie d'une sous-commande, p. ex. \"contest edit\"",
+		"Role of the members":                                                 "Rôle des membres",
+
+		"Sorry, you are not allowed to do that.": "Désolé, vous n'avez pas le droit de faire cela.",
+		"Ask %s for help!":                       "Demandez de l'aide à %s !",
+		"Failed: %v":                             "Échec : %v",
+		"Unknown command %q. Known commands: %s": "Commande %q inconnue. Commandes connues : %s",
+		"Saved!":                                 "Enregistré !",
+		"Server administrators may always use every command. Otherwise:": "Les administrateurs du serveur peuvent toujours utiliser toutes les commandes. Sinon :",
+		"anyone":                     "tout le monde",
+		"members with permission %s": "les membres ayant la permission %s",
+		"No role is allowed anymore, so `/%s` is now for: %s": "Plus aucun rôle n'est autorisé, donc `/%s` est désormais pour : %s",
+	})
+	i18n.Register(language.German, map[string]string{
+		"Control who may use the commands of the bot":                         "Festlegen, wer die Befehle des Bots verwenden darf",
+		"Let members with a role use a command":                               "Mitgliedern mit einer Rolle einen Befehl erlauben",
+		"Stop letting members with a role use a command":                      "Mitgliedern mit einer Rolle einen Befehl nicht mehr erlauben",
+		"Let members with a permission use a command":                         "Mitgliedern mit einer Berechtigung einen Befehl erlauben",
+		"Permission members need, unless they have one of the allowed roles":  "Nötige Berechtigung, sofern keine der erlaubten Rollen vorliegt",
+		"Go back to the default permissions of a command":                     "Standardberechtigungen eines Befehls wiederherstellen",
+		"Show who may use each command":                                       "Anzeigen, wer welchen Befehl verwenden darf",
+		"Command, optionally followed by a subcommand, e.g. \"contest edit\"": "Befehl, optional gefolgt von einem Unterbefehl, z. B. \"contest edit\"",
+		"Role of the members":                                                 "Rolle der Mitglieder",
+
+		"Sorry, you are not allowed to do that.": "Das darfst du leider nicht.",
+		"Ask %s for help!":                       "Frag %s um Hilfe!",
+		"Failed: %v":                             "Fehlgeschlagen: %v",
+		"Unknown command %q. Known commands: %s": "Unbekannter Befehl %q. Bekannte Befehle: %s",
+		"Saved!":                                 "Gespeichert!",
+		"Server administrators may always use every command. Otherwise:": "Server-Administratoren dürfen immer alle Befehle verwenden. Ansonsten:",
+		"anyone":                     "alle",
+		"members with permission %s": "Mitglieder mit der Berechtigung %s",
+		"No role is allowed anymore, so `/%s` is now for: %s": "Keine Rolle ist mehr erlaubt, daher ist `/%s` jetzt für: %s",
+	})
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+	"golang.org/x/text/message"
+)
+
+const (
+	authCommandAllow   = "allow"
+	authCommandDeny    = "deny"
+	authCommandRequire = "require"
+	authCommandReset   = "reset"
+	authCommandShow    = "show"
+
+	authOptionCommand    = "command"
+	authOptionRole       = "role"
+	authOptionPermission = "permission"
+)
+
+var (
+	// permissions that make sense to require, by the name offered as choice
+	requirablePermissions = []requirablePermission{
+		{"anyone", 0},
+		{"manage_server", discordgo.PermissionManageServer},
+		{"manage_events", discordgo.PermissionManageEvents},
+		{"manage_threads", discordgo.PermissionManageThreads},
+		{"manage_messages", discordgo.PermissionManageMessages},
+		{"manage_roles", discordgo.PermissionManageRoles},
+	}
+
+	ApplicationAuthCommand = &discordgo.ApplicationCommand{
+		Type:        discordgo.ChatApplicationCommand,
+		Name:        "permissions",
+		Description: "Control who may use the commands of the bot",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        authCommandAllow,
+				Description: "Let members with a role use a command",
+				Options:     []*discordgo.ApplicationCommandOption{commandOption(), roleOption()},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        authCommandDeny,
+				Description: "Stop letting members with a role use a command",
+				Options:     []*discordgo.ApplicationCommandOption{commandOption(), roleOption()},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        authCommandRequire,
+				Description: "Let members with a permission use a command",
+				Options: []*discordgo.ApplicationCommandOption{
+					commandOption(),
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        authOptionPermission,
+						Description: "Permission members need, unless they have one of the allowed roles",
+						Required:    true,
+						Choices:     permissionChoices(),
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        authCommandReset,
+				Description: "Go back to the default permissions of a command",
+				Options:     []*discordgo.ApplicationCommandOption{commandOption()},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        authCommandShow,
+				Description: "Show who may use each command",
+			},
+		},
+	}
+)
+
+type requirablePermission struct {
+	name string
+	perm int64
+}
+
+func commandOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        authOptionCommand,
+		Description: "Command, optionally followed by a subcommand, e.g. \"contest edit\"",
+		Required:    true,
+	}
+}
+
+func roleOption() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionRole,
+		Name:        authOptionRole,
+		Description: "Role of the members",
+		Required:    true,
+	}
+}
+
+func permissionChoices() []*discordgo.ApplicationCommandOptionChoice {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, rp := range requirablePermissions {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: rp.name, Value: rp.name})
+	}
+	return choices
+}
+
+// commandPaths lists every command, and every subcommand, that rules can be set for.
+func commandPaths(cmds []*discordgo.ApplicationCommand) []string {
+	var paths []string
+	for _, c := range cmds {
+		paths = append(paths, c.Name)
+		for _, o := range c.Options {
+			if o.Type == discordgo.ApplicationCommandOptionSubCommand {
+				paths = append(paths, c.Name+" "+o.Name)
+			}
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+func HandleAuth(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.Printer(i.Interaction)
+
+	if l := len(i.ApplicationCommandData().Options); l != 1 {
+		return fmt.Errorf("invalid options length in %s: %d", ApplicationAuthCommand.Name, l)
+	}
+	o := i.ApplicationCommandData().Options[0]
+
+	msg, err := handleAuthSubcommand(s, p, i.GuildID, o)
+	if err != nil {
+		msg = p.Sprintf("Failed: %v", err)
+	}
+	return s.InteractionRespond(i.Interaction,
+		&discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content:         msg,
+				Flags:           discordgo.MessageFlagsEphemeral,
+				AllowedMentions: &discordgo.MessageAllowedMentions{},
+			},
+		})
+}
+
+func handleAuthSubcommand(s *discordgo.Session, p *message.Printer, guildID string, o *discordgo.ApplicationCommandInteractionDataOption) (string, error) {
+	cfg, err := loadAuthConfig(guildID)
+	if err != nil {
+		return "", err
+	}
+	if o.Name == authCommandShow {
+		return describeAuthConfig(p, cfg), nil
+	}
+
+	var path string
+	var role *discordgo.Role
+	var permission string
+	for _, opt := range o.Options {
+		switch opt.Name {
+		case authOptionCommand:
+			path = strings.Join(strings.Fields(strings.TrimPrefix(opt.StringValue(), "/")), " ")
+		case authOptionRole:
+			role = opt.RoleValue(s, guildID)
+		case authOptionPermission:
+			permission = opt.StringValue()
+		}
+	}
+	if !slices.Contains(knownPaths, path) {
+		return p.Sprintf("Unknown command %q. Known commands: %s", path, strings.Join(knownPaths, ", ")), nil
+	}
+
+	rule := cfg.rule(path)
+	var note string
+	switch o.Name {
+	case authCommandAllow:
+		if !slices.Contains(rule.Roles, role.ID) {
+			rule.Roles = append(slices.Clone(rule.Roles), role.ID)
+		}
+		cfg.Rules[path] = rule
+	case authCommandDeny:
+		// the rule may be the one of the command, which is not meant to change
+		rule.Roles = slices.DeleteFunc(slices.Clone(rule.Roles), func(r string) bool { return r == role.ID })
+		cfg.Rules[path] = rule
+		// a rule with neither permissions nor roles lets anyone in, which denying a role must not lead to
+		if rule.Permissions == 0 && len(rule.Roles) == 0 {
+			cfg.denyFallback(path, role.ID)
+			note = "\n" + p.Sprintf("No role is allowed anymore, so `/%s` is now for: %s", path, describeRule(p, cfg.rule(path)))
+		}
+	case authCommandRequire:
+		idx := slices.IndexFunc(requirablePermissions, func(rp requirablePermission) bool {
+			return rp.name == permission
+		})
+		if idx < 0 {
+			return "", fmt.Errorf("unknown permission %q", permission)
+		}
+		rule.Permissions = requirablePermissions[idx].perm
+		cfg.Rules[path] = rule
+	case authCommandReset:
+		delete(cfg.Rules, path)
+	default:
+		return "", fmt.Errorf("unknown subcommand %q", o.Name)
+	}
+
+	if err := saveAuthConfig(guildID, cfg); err != nil {
+		return "", err
+	}
+	return p.Sprintf("Saved!") + note + "\n" + describeAuthConfig(p, cfg), nil
+}
+
+func describeAuthConfig(p *message.Printer, cfg authConfig) string {
+	lines := []string{p.Sprintf("Server administrators may always use every command. Otherwise:")}
+	for _, path := range knownPaths {
+		lines = append(lines, fmt.Sprintf("- `/%s`: %s", path, describeRule(p, cfg.rule(path))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func describeRule(p *message.Printer, rule authRule) string {
+	var who []string
+	if rule.Permissions == 0 && len(rule.Roles) == 0 {
+		who = append(who, p.Sprintf("anyone"))
+	}
+	if rule.Permissions != 0 {
+		who = append(who, p.Sprintf("members with permission %s", permissionName(rule.Permissions)))
+	}
+	for _, r := range rule.Roles {
+		who = append(who, "<@&"+r+">")
+	}
+	return strings.Join(who, ", ")
+}
+
+func permissionName(perm int64) string {
+	for _, rp := range requirablePermissions {
+		if rp.perm == perm {
+			return rp.name
+		}
+	}
+	return fmt.Sprint(perm)
+}
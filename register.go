@@ -4,12 +4,15 @@ import (
 	"log"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
 )
 
 func registerCommands(s *discordgo.Session, appID, guildID string) error {
 	log.Println("Registering commands...")
 
 	for c := range commands {
+		i18n.LocalizeCommand(c)
+		setDefaultPermissions(c)
 		cmd, err := s.ApplicationCommandCreate(appID, guildID, c)
 		if err != nil {
 			return err
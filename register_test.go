@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/itizir/hrv/i18n"
+)
+
+// limits Discord enforces when commands are registered
+const (
+	maxCommandNameLength        = 32
+	maxCommandDescriptionLength = 100
+	maxChoiceNameLength         = 100
+)
+
+func TestCommandLengths(t *testing.T) {
+	checkName := func(path, name string) {
+		if l := utf8.RuneCountInString(name); l == 0 || l > maxCommandNameLength {
+			t.Errorf("%s: name %q is %d characters long", path, name, l)
+		}
+	}
+	checkDescription := func(path, desc string) {
+		if l := utf8.RuneCountInString(desc); l == 0 || l > maxCommandDescriptionLength {
+			t.Errorf("%s: description %q is %d characters long", path, desc, l)
+		}
+	}
+
+	var checkOptions func(path string, opts []*discordgo.ApplicationCommandOption)
+	checkOptions = func(path string, opts []*discordgo.ApplicationCommandOption) {
+		for _, o := range opts {
+			p := path + " " + o.Name
+			checkName(p, o.Name)
+			checkDescription(p, o.Description)
+			for l, n := range o.NameLocalizations {
+				checkName(p+" ("+string(l)+")", n)
+			}
+			for l, d := range o.DescriptionLocalizations {
+				checkDescription(p+" ("+string(l)+")", d)
+			}
+			for _, c := range o.Choices {
+				if l := utf8.RuneCountInString(c.Name); l == 0 || l > maxChoiceNameLength {
+					t.Errorf("%s: choice %q is %d characters long", p, c.Name, l)
+				}
+			}
+			checkOptions(p, o.Options)
+		}
+	}
+
+	for c := range commands {
+		i18n.LocalizeCommand(c)
+		checkName(c.Name, c.Name)
+		checkDescription(c.Name, c.Description)
+		if c.NameLocalizations != nil {
+			for l, n := range *c.NameLocalizations {
+				checkName(c.Name+" ("+string(l)+")", n)
+			}
+		}
+		if c.DescriptionLocalizations != nil {
+			for l, d := range *c.DescriptionLocalizations {
+				checkDescription(c.Name+" ("+string(l)+")", d)
+			}
+		}
+		checkOptions(c.Name, c.Options)
+	}
+}
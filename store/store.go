@@ -0,0 +1,125 @@
+// Package store persists small JSON documents on the local file system.
+//
+// Documents are grouped in collections, which map to directories under Dir,
+// and are written atomically so that a crash never leaves a half-written file behind.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const ext = ".json"
+
+// Dir is the root directory of all collections.
+var Dir = "data"
+
+func init() {
+	if env := os.Getenv("DATA_DIR"); env != "" {
+		Dir = env
+	}
+}
+
+// guards against concurrent writers within the process; documents are small, so no need for anything finer.
+var mu sync.RWMutex
+
+// Collection returns the name of a collection nested under another one.
+func Collection(parts ...string) string {
+	escaped := make([]string, len(parts))
+	for i, p := range parts {
+		escaped[i] = url.PathEscape(p)
+	}
+	return filepath.Join(escaped...)
+}
+
+func path(collection, key string) string {
+	return filepath.Join(Dir, collection, url.PathEscape(key)+ext)
+}
+
+// Get decodes the document into v, and reports whether it existed.
+func Get(collection, key string, v any) (bool, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	b, err := os.ReadFile(path(collection, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(b, v)
+}
+
+// Put creates or replaces the document.
+func Put(collection, key string, v any) error {
+	b, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	p := path(collection, key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+// Delete removes the document. Deleting a missing document is not an error.
+func Delete(collection, key string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	err := os.Remove(path(collection, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Keys lists the keys of all documents in the collection, sorted.
+func Keys(collection string) ([]string, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	entries, err := os.ReadDir(filepath.Join(Dir, collection))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ext)
+		if e.IsDir() || !ok || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if k, err := url.PathUnescape(name); err == nil {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys, nil
+}